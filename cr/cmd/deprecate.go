@@ -0,0 +1,76 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/helm/chart-releaser/pkg/config"
+	"github.com/helm/chart-releaser/pkg/git"
+	"github.com/helm/chart-releaser/pkg/github"
+	"github.com/helm/chart-releaser/pkg/releaser"
+	"github.com/spf13/cobra"
+)
+
+// deprecateCmd represents the deprecate command
+var deprecateCmd = &cobra.Command{
+	Use:   "deprecate CHART",
+	Short: "Mark chart versions as deprecated in the Helm repo index.yaml",
+	Long: `
+Mark the versions of a chart matching --version-range (all versions by
+default) as deprecated in the index.yaml and add a deprecation note to
+their GitHub releases.
+	`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := config.LoadConfiguration(cfgFile, cmd, getRequiredDeprecateArgs())
+		if err != nil {
+			return err
+		}
+		ghc := github.NewClient(config.Owner, config.GitRepo, config.Token, config.GitBaseURL, config.GitUploadURL)
+		releaser := releaser.NewReleaser(config, ghc, &git.Git{})
+		if err := releaser.DeprecateChart(args[0]); err != nil {
+			return err
+		}
+		if config.DryRun {
+			return printPlan(config, releaser.Plan())
+		}
+		return nil
+	},
+}
+
+func getRequiredDeprecateArgs() []string {
+	return []string{"owner", "git-repo", "token"}
+}
+
+func init() {
+	rootCmd.AddCommand(deprecateCmd)
+	flags := deprecateCmd.Flags()
+	flags.StringP("owner", "o", "", "GitHub username or organization")
+	flags.StringP("git-repo", "r", "", "GitHub repository")
+	flags.StringP("index-path", "i", ".cr-index/index.yaml", "Path to index file")
+	flags.StringP("token", "t", "", "GitHub Auth Token")
+	flags.StringP("git-base-url", "b", "https://api.github.com/", "GitHub Base URL (only needed for private GitHub)")
+	flags.StringP("git-upload-url", "u", "https://uploads.github.com/", "GitHub Upload URL (only needed for private GitHub)")
+	flags.String("pages-branch", "gh-pages", "The GitHub pages branch")
+	flags.String("pages-index-path", "index.yaml", "The GitHub pages index path")
+	flags.String("remote", "origin", "The Git remote used when creating a local worktree for the GitHub Pages branch")
+	flags.Bool("push", false, "Push the index.yaml change to the GitHub Pages branch (must not be set if --pr is set)")
+	flags.Bool("pr", false, "Create a pull request for the index.yaml change against the GitHub Pages branch (must not be set if --push is set)")
+	flags.String("release-name-template", "{{ .Name }}-{{ .Version }}", "Go template for computing release names, using chart metadata")
+	flags.String("version-range", "", "Semver range of the chart versions to deprecate, e.g. '< 2.0.0'. All versions are deprecated by default")
+	flags.String("deprecation-message", "This chart version is deprecated.", "Deprecation note added to the GitHub releases")
+	flags.Bool("dry-run", false, "Compute the changes without writing the index file, updating releases or pushing to the GitHub Pages branch")
+	flags.String("plan-format", "text", "Format of the dry-run plan: text or json")
+	flags.String("plan-file", "", "Write the dry-run plan to the given file instead of stdout")
+}
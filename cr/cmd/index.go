@@ -49,8 +49,13 @@ given GitHub repository's releases.
 
 		ghc := github.NewClient(config.Owner, config.GitRepo, config.Token, config.GitBaseURL, config.GitUploadURL)
 		releaser := releaser.NewReleaser(config, ghc, &git.Git{})
-		_, err = releaser.UpdateIndexFile()
-		return err
+		if _, err = releaser.UpdateIndexFile(); err != nil {
+			return err
+		}
+		if config.DryRun {
+			return printPlan(config, releaser.Plan())
+		}
+		return nil
 	},
 }
 
@@ -77,4 +82,15 @@ func init() {
 	flags.Bool("pr", false, "Create a pull request for index.yaml against the GitHub Pages branch (must not be set if --push is set)")
 	flags.String("release-name-template", "{{ .Name }}-{{ .Version }}", "Go template for computing release names, using chart metadata")
 	flags.Bool("packages-with-index", false, "Host the package files in the GitHub Pages branch")
+	flags.Int("concurrency", 1, "Maximum number of GitHub releases looked up concurrently")
+	flags.Bool("dry-run", false, "Compute the index changes without writing the index file or pushing to the GitHub Pages branch")
+	flags.String("plan-format", "text", "Format of the dry-run plan: text or json")
+	flags.String("plan-file", "", "Write the dry-run plan to the given file instead of stdout")
+	flags.Bool("rebuild", false, "Rebuild the index from the chart packages of all GitHub releases instead of updating it")
+	flags.Bool("overwrite", false, "Replace the index entries of chart versions already in the index with the current packages, e.g. after re-publishing them with 'cr upload --overwrite'")
+	flags.Bool("prune", false, "Remove chart versions from the index whose GitHub release no longer exists")
+	flags.Int("retain-versions", 0, "Retention policy: keep only the given number of most recent versions of each chart (0 keeps all)")
+	flags.Duration("retain-newer-than", 0, "Retention policy: keep all chart versions created within the given duration, e.g. 2160h")
+	flags.Bool("retain-latest-patch", false, "Retention policy: always keep the latest patch version of each minor version")
+	flags.Bool("retention-delete-releases", false, "Delete the GitHub releases and git tags of chart versions removed by the retention policy once the index is pushed. Requires --push")
 }
@@ -16,6 +16,7 @@ package cmd
 
 import (
 	"github.com/helm/chart-releaser/pkg/config"
+	"github.com/helm/chart-releaser/pkg/git"
 	"github.com/helm/chart-releaser/pkg/packager"
 	"github.com/spf13/cobra"
 )
@@ -26,7 +27,26 @@ var packageCmd = &cobra.Command{
 	Short: "Package Helm charts",
 	Long: `This command packages a chart into a versioned chart archive file. If a path
 is given, this will look at that path for a chart (which must contain a
-Chart.yaml file) and then package that directory.
+Chart.yaml file) and then package that directory. A path without a
+Chart.yaml is searched recursively for charts matching the --include and
+--exclude patterns, skipping subcharts vendored in the charts directory of a chart.
+
+With --changed-since or --changed-since-release only the charts changed since
+the given git ref or their latest release are packaged, including uncommitted
+changes, together with the charts depending on them through file://
+repositories.
+
+Charts depending on other charts being packaged through file:// repositories
+are packaged after them. Independent charts are packaged concurrently, up to
+--concurrency at a time.
+
+With --lint each chart is linted and rendered once per values file matching
+--lint-values before it is packaged. Any lint error or rendering failure blocks
+packaging.
+
+With --reproducible packaging the same chart sources always results in the same
+package bytes. File timestamps are taken from SOURCE_DATE_EPOCH or else from the
+last commit touching the chart. Signed packages are signed after normalizing.
 
 
 If you wish to use advanced packaging options such as creating signed
@@ -41,7 +61,7 @@ packages or updating chart dependencies please use "helm package" instead.`,
 			return err
 		}
 
-		p := packager.NewPackager(config, args)
+		p := packager.NewPackager(config, args, &git.Git{})
 		return p.CreatePackages()
 
 	},
@@ -57,5 +77,15 @@ func init() {
 	packageCmd.Flags().Bool("sign", false, "Use a PGP private key to sign this package")
 	packageCmd.Flags().String("key", "", "Name of the key to use when signing")
 	packageCmd.Flags().String("keyring", "~/.gnupg/pubring.gpg", "Location of a public keyring")
+	packageCmd.Flags().Int("concurrency", 1, "Maximum number of independent charts packaged concurrently")
+	packageCmd.Flags().StringSlice("include", nil, "Glob patterns of the chart directories to package below a root, relative to the root. Patterns without a slash match the directory name")
+	packageCmd.Flags().StringSlice("exclude", nil, "Glob patterns of the chart directories to skip below a root, relative to the root. Patterns without a slash match the directory name")
+	packageCmd.Flags().Bool("list", false, "Print the charts that would be packaged without packaging them")
+	packageCmd.Flags().Bool("lint", false, "Lint each chart and render its templates like a client-only dry run install before packaging it. Any problem blocks packaging")
+	packageCmd.Flags().StringSlice("lint-values", []string{"ci/*-values.yaml"}, "Glob patterns of the values files to lint and render each chart with, relative to the chart directory. Without a match the default values are used")
+	packageCmd.Flags().Bool("reproducible", false, "Create byte-identical packages for identical chart sources by normalizing timestamps, ownership and file order. Timestamps are taken from SOURCE_DATE_EPOCH or the last commit touching the chart")
+	packageCmd.Flags().String("changed-since", "", "Package only the charts changed since the given git ref")
+	packageCmd.Flags().Bool("changed-since-release", false, "Package only the charts changed since their latest release tag, recognized by --release-name-template")
+	packageCmd.Flags().String("release-name-template", "{{ .Name }}-{{ .Version }}", "Go template for computing release names, using chart metadata")
 	packageCmd.Flags().String("passphrase-file", "", "Location of a file which contains the passphrase for the signing key. Use '-' in order to read from stdin")
 }
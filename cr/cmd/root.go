@@ -15,8 +15,11 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
 
+	"github.com/helm/chart-releaser/pkg/config"
+	"github.com/helm/chart-releaser/pkg/releaser"
 	"github.com/spf13/cobra"
 )
 
@@ -37,6 +40,25 @@ func Execute() {
 	}
 }
 
+// printPlan prints the plan of a dry run in the configured format, either to
+// stdout or to the configured plan file.
+func printPlan(config *config.Options, plan *releaser.Plan) error {
+	res := plan.String()
+	if config.PlanFormat == "json" {
+		j, err := plan.JSONString()
+		if err != nil {
+			return fmt.Errorf("unable to generate JSON from plan: %w", err)
+		}
+		res = j + "\n"
+	}
+
+	if config.PlanFile != "" {
+		return os.WriteFile(config.PlanFile, []byte(res), 0644) // nolint: gosec
+	}
+	fmt.Print(res)
+	return nil
+}
+
 func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "Config file (default is $HOME/.cr.yaml)")
 }
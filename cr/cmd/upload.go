@@ -15,6 +15,8 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/helm/chart-releaser/pkg/config"
 	"github.com/helm/chart-releaser/pkg/git"
 	"github.com/helm/chart-releaser/pkg/github"
@@ -34,7 +36,13 @@ var uploadCmd = &cobra.Command{
 		}
 		ghc := github.NewClient(config.Owner, config.GitRepo, config.Token, config.GitBaseURL, config.GitUploadURL)
 		releaser := releaser.NewReleaser(config, ghc, &git.Git{})
-		return releaser.CreateReleases()
+		if err := releaser.CreateReleases(); err != nil {
+			return err
+		}
+		if config.DryRun {
+			return printPlan(config, releaser.Plan())
+		}
+		return nil
 	},
 }
 
@@ -52,14 +60,45 @@ func init() {
 	uploadCmd.Flags().StringP("git-upload-url", "u", "https://uploads.github.com/", "GitHub Upload URL (only needed for private GitHub)")
 	uploadCmd.Flags().StringP("commit", "c", "", "Target commit for release")
 	uploadCmd.Flags().Bool("skip-existing", false, "Skip upload if release exists")
-	uploadCmd.Flags().String("release-name-template", "{{ .Name }}-{{ .Version }}", "Go template for computing release names, using chart metadata")
+	uploadCmd.Flags().Bool("overwrite", false, "Replace the chart packages and other assets of an existing release and update its body. Must not be combined with --resume or --skip-existing. Run 'cr index --overwrite' afterwards to update the digests in the index")
+	uploadCmd.Flags().Duration("overwrite-max-age", 24*time.Hour, "Refuse to overwrite releases created longer ago than the given duration (0 disables the check)")
+	uploadCmd.Flags().Bool("resume", false, "Upload missing assets to an existing release instead of skipping it or failing. "+
+		"Fails if an existing asset differs from the local file")
+	uploadCmd.Flags().String("release-name-template", "{{ .Name }}-{{ .Version }}", "Go template for computing release names, using chart metadata. "+
+		"Chart packages rendering to the same release name are published in a single release")
 	uploadCmd.Flags().String("release-notes-file", "", "Markdown file with chart release notes. "+
 		"If it is set to empty string, or the file is not found, the chart description will be used instead. The file is read from the chart package")
+	uploadCmd.Flags().String("changelog-file", "", "Changelog file in the chart package whose section for the chart version is used as release notes. The file is read from the chart package")
+	uploadCmd.Flags().String("changelog-format", "keepachangelog", "Format of the changelog file: keepachangelog or markdown")
+	uploadCmd.Flags().String("changelog-heading-pattern", "", "Go template for a regular expression matching the changelog section heading, using chart metadata. "+
+		"The function quoteMeta escapes values, e.g. '^## {{ quoteMeta .Version }}'. Overrides the pattern of the changelog format")
+	uploadCmd.Flags().String("changelog-fallback", "notes", "Behaviour if the changelog has no section for the chart version: "+
+		"notes (use the release notes file or chart description), empty or error")
+	uploadCmd.Flags().String("release-notes-template", "", "Go template file for the release notes. It is rendered with the chart metadata (.Chart), the release name (.ReleaseName), "+
+		"the target commit (.Commit), the tag of the previous release of the chart (.PreviousRelease) and the commits touching the chart directory since then (.Commits)")
+	uploadCmd.Flags().Bool("release-notes-from-git", false, "Build the release notes from the commits touching the chart directory since the previous release of the chart")
+	uploadCmd.Flags().Bool("group-commits-by-type", false, "Group the commits in release notes built from git by their Conventional Commits type")
+	uploadCmd.Flags().Bool("artifacthub-changes", false, "Append the changes listed in the artifacthub.io/changes annotation of the chart to the release notes")
+	uploadCmd.Flags().String("checksums-file", "", "Name of a checksum manifest listing the SHA256 digests of all assets to attach to each release, e.g. 'SHA256SUMS'. "+
+		"It is a Go template using chart metadata, e.g. '{{ .Name }}-{{ .Version }}.sha256'")
+	uploadCmd.Flags().String("charts-dir", "charts", "Directory holding the chart sources in directories named like the charts. Used to find the commits touching a chart, which fails if the chart has no directory there")
+	uploadCmd.Flags().StringSlice("extra-assets", nil, "Glob patterns of additional files to attach to the release of each chart. Patterns are Go templates using chart metadata, "+
+		"e.g. 'charts/{{ .Name }}/values.schema.json'")
 	uploadCmd.Flags().Bool("generate-release-notes", false, "Whether to automatically generate the name and body for this release. See https://docs.github.com/en/rest/releases/releases")
-	uploadCmd.Flags().Bool("make-release-latest", true, "Mark the created GitHub release as 'latest'")
+	uploadCmd.Flags().Bool("atomic", false, "Delete all GitHub releases and tags created in this run if anything fails. Implies --draft-release")
+	uploadCmd.Flags().Bool("draft-release", false, "Create the GitHub release as draft and publish it only once all assets were uploaded. "+
+		"The draft is deleted if an upload fails")
+	uploadCmd.Flags().Bool("make-release-latest", true, "Mark the created GitHub release as 'latest'. Releases of semver prerelease versions are created as prereleases and never marked as 'latest'")
+	uploadCmd.Flags().String("latest-policy", "always", "Which releases are marked as 'latest' if --make-release-latest is set: always (every release) or highest "+
+		"(only a release with a higher version than all existing releases, see --primary-chart)")
+	uploadCmd.Flags().String("primary-chart", "", "Name of the chart whose releases are considered for the highest latest policy. By default the releases of all charts are considered")
 	uploadCmd.Flags().String("pages-branch", "gh-pages", "The GitHub pages branch")
 	uploadCmd.Flags().String("remote", "origin", "The Git remote used when creating a local worktree for the GitHub Pages branch")
 	uploadCmd.Flags().Bool("push", false, "Push the chart package to the GitHub Pages branch (must not be set if --pr is set)")
 	uploadCmd.Flags().Bool("pr", false, "Create a pull request for the chart package against the GitHub Pages branch (must not be set if --push is set)")
 	uploadCmd.Flags().Bool("packages-with-index", false, "Host the package files in the GitHub Pages branch")
+	uploadCmd.Flags().Bool("dry-run", false, "Compute the releases to create without creating them or pushing to the GitHub Pages branch")
+	uploadCmd.Flags().String("plan-format", "text", "Format of the dry-run plan: text or json")
+	uploadCmd.Flags().String("plan-file", "", "Write the dry-run plan to the given file instead of stdout")
+	uploadCmd.Flags().Int("concurrency", 1, "Maximum number of GitHub releases created concurrently")
 }
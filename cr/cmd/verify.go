@@ -0,0 +1,58 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/helm/chart-releaser/pkg/config"
+	"github.com/helm/chart-releaser/pkg/git"
+	"github.com/helm/chart-releaser/pkg/github"
+	"github.com/helm/chart-releaser/pkg/releaser"
+	"github.com/spf13/cobra"
+)
+
+// verifyCmd represents the verify command
+var verifyCmd = &cobra.Command{
+	Use:   "verify TAG [TAG...]",
+	Short: "Verify the assets of GitHub Releases against their checksum manifest",
+	Long: `
+Download the assets of the given GitHub releases and verify them against
+the checksum manifest of the release (SHA256SUMS or *.sha256) created by
+'cr upload --checksums-file'.
+	`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := config.LoadConfiguration(cfgFile, cmd, getRequiredVerifyArgs())
+		if err != nil {
+			return err
+		}
+		ghc := github.NewClient(config.Owner, config.GitRepo, config.Token, config.GitBaseURL, config.GitUploadURL)
+		releaser := releaser.NewReleaser(config, ghc, &git.Git{})
+		return releaser.VerifyReleases(args)
+	},
+}
+
+func getRequiredVerifyArgs() []string {
+	return []string{"owner", "git-repo"}
+}
+
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+	flags := verifyCmd.Flags()
+	flags.StringP("owner", "o", "", "GitHub username or organization")
+	flags.StringP("git-repo", "r", "", "GitHub repository")
+	flags.StringP("token", "t", "", "GitHub Auth Token (only needed for private repos)")
+	flags.StringP("git-base-url", "b", "https://api.github.com/", "GitHub Base URL (only needed for private GitHub)")
+	flags.StringP("git-upload-url", "u", "https://uploads.github.com/", "GitHub Upload URL (only needed for private GitHub)")
+}
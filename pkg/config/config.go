@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/mitchellh/go-homedir"
 
@@ -38,30 +39,68 @@ var (
 )
 
 type Options struct {
-	Owner                string `mapstructure:"owner"`
-	GitRepo              string `mapstructure:"git-repo"`
-	ChartsRepo           string `mapstructure:"charts-repo"`
-	IndexPath            string `mapstructure:"index-path"`
-	PackagePath          string `mapstructure:"package-path"`
-	Sign                 bool   `mapstructure:"sign"`
-	Key                  string `mapstructure:"key"`
-	KeyRing              string `mapstructure:"keyring"`
-	PassphraseFile       string `mapstructure:"passphrase-file"`
-	Token                string `mapstructure:"token"`
-	GitBaseURL           string `mapstructure:"git-base-url"`
-	GitUploadURL         string `mapstructure:"git-upload-url"`
-	Commit               string `mapstructure:"commit"`
-	PagesBranch          string `mapstructure:"pages-branch"`
-	PagesIndexPath       string `mapstructure:"pages-index-path"`
-	Push                 bool   `mapstructure:"push"`
-	PR                   bool   `mapstructure:"pr"`
-	Remote               string `mapstructure:"remote"`
-	ReleaseNameTemplate  string `mapstructure:"release-name-template"`
-	SkipExisting         bool   `mapstructure:"skip-existing"`
-	ReleaseNotesFile     string `mapstructure:"release-notes-file"`
-	GenerateReleaseNotes bool   `mapstructure:"generate-release-notes"`
-	MakeReleaseLatest    bool   `mapstructure:"make-release-latest"`
-	PackagesWithIndex    bool   `mapstructure:"packages-with-index"`
+	Owner                   string        `mapstructure:"owner"`
+	GitRepo                 string        `mapstructure:"git-repo"`
+	ChartsRepo              string        `mapstructure:"charts-repo"`
+	IndexPath               string        `mapstructure:"index-path"`
+	PackagePath             string        `mapstructure:"package-path"`
+	Sign                    bool          `mapstructure:"sign"`
+	Key                     string        `mapstructure:"key"`
+	KeyRing                 string        `mapstructure:"keyring"`
+	PassphraseFile          string        `mapstructure:"passphrase-file"`
+	Token                   string        `mapstructure:"token"`
+	GitBaseURL              string        `mapstructure:"git-base-url"`
+	GitUploadURL            string        `mapstructure:"git-upload-url"`
+	Commit                  string        `mapstructure:"commit"`
+	PagesBranch             string        `mapstructure:"pages-branch"`
+	PagesIndexPath          string        `mapstructure:"pages-index-path"`
+	Push                    bool          `mapstructure:"push"`
+	PR                      bool          `mapstructure:"pr"`
+	Remote                  string        `mapstructure:"remote"`
+	ReleaseNameTemplate     string        `mapstructure:"release-name-template"`
+	SkipExisting            bool          `mapstructure:"skip-existing"`
+	ReleaseNotesFile        string        `mapstructure:"release-notes-file"`
+	GenerateReleaseNotes    bool          `mapstructure:"generate-release-notes"`
+	MakeReleaseLatest       bool          `mapstructure:"make-release-latest"`
+	PackagesWithIndex       bool          `mapstructure:"packages-with-index"`
+	Rebuild                 bool          `mapstructure:"rebuild"`
+	Prune                   bool          `mapstructure:"prune"`
+	RetainVersions          int           `mapstructure:"retain-versions"`
+	RetainNewerThan         time.Duration `mapstructure:"retain-newer-than"`
+	RetainLatestPatch       bool          `mapstructure:"retain-latest-patch"`
+	RetentionDeleteReleases bool          `mapstructure:"retention-delete-releases"`
+	Concurrency             int           `mapstructure:"concurrency"`
+	DryRun                  bool          `mapstructure:"dry-run"`
+	PlanFormat              string        `mapstructure:"plan-format"`
+	PlanFile                string        `mapstructure:"plan-file"`
+	Resume                  bool          `mapstructure:"resume"`
+	DraftRelease            bool          `mapstructure:"draft-release"`
+	Atomic                  bool          `mapstructure:"atomic"`
+	ChangelogFile           string        `mapstructure:"changelog-file"`
+	ChangelogFormat         string        `mapstructure:"changelog-format"`
+	ChangelogHeadingPattern string        `mapstructure:"changelog-heading-pattern"`
+	ChangelogFallback       string        `mapstructure:"changelog-fallback"`
+	ReleaseNotesTemplate    string        `mapstructure:"release-notes-template"`
+	ChartsDir               string        `mapstructure:"charts-dir"`
+	ReleaseNotesFromGit     bool          `mapstructure:"release-notes-from-git"`
+	GroupCommitsByType      bool          `mapstructure:"group-commits-by-type"`
+	ArtifactHubChanges      bool          `mapstructure:"artifacthub-changes"`
+	ExtraAssets             []string      `mapstructure:"extra-assets"`
+	ChecksumsFile           string        `mapstructure:"checksums-file"`
+	LatestPolicy            string        `mapstructure:"latest-policy"`
+	PrimaryChart            string        `mapstructure:"primary-chart"`
+	Overwrite               bool          `mapstructure:"overwrite"`
+	OverwriteMaxAge         time.Duration `mapstructure:"overwrite-max-age"`
+	VersionRange            string        `mapstructure:"version-range"`
+	DeprecationMessage      string        `mapstructure:"deprecation-message"`
+	ChangedSince            string        `mapstructure:"changed-since"`
+	ChangedSinceRelease     bool          `mapstructure:"changed-since-release"`
+	Include                 []string      `mapstructure:"include"`
+	Exclude                 []string      `mapstructure:"exclude"`
+	List                    bool          `mapstructure:"list"`
+	Lint                    bool          `mapstructure:"lint"`
+	LintValues              []string      `mapstructure:"lint-values"`
+	Reproducible            bool          `mapstructure:"reproducible"`
 }
 
 func LoadConfiguration(cfgFile string, cmd *cobra.Command, requiredFlags []string) (*Options, error) {
@@ -108,6 +147,26 @@ func LoadConfiguration(cfgFile string, cmd *cobra.Command, requiredFlags []strin
 		return nil, errors.New("specify either --push or --pr, but not both")
 	}
 
+	if opts.ChangedSince != "" && opts.ChangedSinceRelease {
+		return nil, errors.New("specify either --changed-since or --changed-since-release, but not both")
+	}
+
+	if opts.RetentionDeleteReleases && !opts.Push {
+		return nil, errors.New("--retention-delete-releases requires --push, releases are only deleted once the index no longer references them")
+	}
+
+	if opts.Overwrite && (opts.Resume || opts.SkipExisting) {
+		return nil, errors.New("--overwrite must not be combined with --resume or --skip-existing")
+	}
+
+	if opts.PlanFormat != "" && opts.PlanFormat != "text" && opts.PlanFormat != "json" {
+		return nil, fmt.Errorf("invalid plan format %q, must be text or json", opts.PlanFormat)
+	}
+
+	if opts.LatestPolicy != "" && opts.LatestPolicy != "always" && opts.LatestPolicy != "highest" {
+		return nil, fmt.Errorf("invalid latest policy %q, must be always or highest", opts.LatestPolicy)
+	}
+
 	elem := reflect.ValueOf(opts).Elem()
 	for _, requiredFlag := range requiredFlags {
 		fieldName := kebabCaseToTitleCamelCase(requiredFlag)
@@ -18,11 +18,24 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type Git struct{}
 
+// Commit is a commit returned by Log
+type Commit struct {
+	Hash    string
+	Author  string
+	Time    time.Time
+	Subject string
+}
+
+// logFieldSeparator separates the fields of a commit in the output of 'git log'
+const logFieldSeparator = "\x1f"
+
 // AddWorktree creates a new Git worktree with a detached HEAD for the given commit-ish and returns its path.
 func (g *Git) AddWorktree(workingDir string, commitIsh string) (string, error) {
 	dir, err := os.MkdirTemp("", "chart-releaser-")
@@ -91,9 +104,68 @@ func (g *Git) GetPushURL(remote string, token string) (string, error) {
 	return pushURLWithToken, nil
 }
 
+// Tags runs 'git tag --list' and returns all tags.
+func (g *Git) Tags(workingDir string) ([]string, error) {
+	command := exec.Command("git", "tag", "--list")
+	output, err := runCommandOutput(workingDir, command)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(output), nil
+}
+
+// Log runs 'git log' for the given revision range and returns the commits which
+// touched any of the given paths, newest first.
+func (g *Git) Log(workingDir string, revisionRange string, paths ...string) ([]Commit, error) {
+	logArgs := make([]string, 0, 4+len(paths))
+	logArgs = append(logArgs, "log", "--format=%H"+logFieldSeparator+"%an"+logFieldSeparator+"%ct"+logFieldSeparator+"%s", revisionRange, "--")
+	logArgs = append(logArgs, paths...)
+	command := exec.Command("git", logArgs...)
+	output, err := runCommandOutput(workingDir, command)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []Commit
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		fields := strings.SplitN(line, logFieldSeparator, 4)
+		if len(fields) != 4 {
+			continue
+		}
+		timestamp, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, Commit{Hash: fields[0], Author: fields[1], Time: time.Unix(timestamp, 0), Subject: fields[3]})
+	}
+	return commits, nil
+}
+
+// Diff runs 'git diff --name-only' against the given ref and returns the files
+// below the given paths which differ between the ref and the working tree,
+// including uncommitted changes.
+func (g *Git) Diff(workingDir string, ref string, paths ...string) ([]string, error) {
+	diffArgs := make([]string, 0, 4+len(paths))
+	diffArgs = append(diffArgs, "diff", "--name-only", ref, "--")
+	diffArgs = append(diffArgs, paths...)
+	command := exec.Command("git", diffArgs...)
+	output, err := runCommandOutput(workingDir, command)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(output), nil
+}
+
 func runCommand(workingDir string, command *exec.Cmd) error {
 	command.Dir = workingDir
 	command.Stdout = os.Stdout
 	command.Stderr = os.Stderr
 	return command.Run()
 }
+
+func runCommandOutput(workingDir string, command *exec.Cmd) (string, error) {
+	command.Dir = workingDir
+	command.Stderr = os.Stderr
+	output, err := command.Output()
+	return string(output), err
+}
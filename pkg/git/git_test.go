@@ -17,7 +17,9 @@ package git
 import (
 	"os"
 	"os/exec"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -83,3 +85,57 @@ func TestGit_GetPushURL(t *testing.T) {
 		})
 	}
 }
+
+func TestGit_TagsLogAndDiff(t *testing.T) {
+	repoPath := t.TempDir()
+	run := func(args ...string) {
+		command := exec.Command("git", append([]string{"-c", "user.name=Jane Doe", "-c", "user.email=jane@example.com"}, args...)...)
+		command.Dir = repoPath
+		output, err := command.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+	commit := func(path string, message string) {
+		require.NoError(t, os.MkdirAll(filepath.Join(repoPath, filepath.Dir(path)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(repoPath, path), []byte(message), 0644))
+		run("add", path)
+		run("commit", "--message", message)
+	}
+
+	run("init")
+	commit("charts/foo/Chart.yaml", "Add foo")
+	run("tag", "foo-0.1.0")
+	commit("charts/bar/Chart.yaml", "Add bar")
+	commit("charts/foo/values.yaml", "Update foo values")
+	run("tag", "bar-0.1.0")
+
+	g := Git{}
+	tags, err := g.Tags(repoPath)
+	require.NoError(t, err)
+	require.Equal(t, []string{"bar-0.1.0", "foo-0.1.0"}, tags)
+
+	commits, err := g.Log(repoPath, "foo-0.1.0..HEAD", "charts/foo")
+	require.NoError(t, err)
+	require.Len(t, commits, 1)
+	require.Equal(t, "Update foo values", commits[0].Subject)
+	require.Equal(t, "Jane Doe", commits[0].Author)
+	require.Len(t, commits[0].Hash, 40)
+	require.WithinDuration(t, time.Now(), commits[0].Time, time.Minute)
+
+	commits, err = g.Log(repoPath, "HEAD", "charts")
+	require.NoError(t, err)
+	require.Len(t, commits, 3)
+	require.Equal(t, "Add foo", commits[2].Subject)
+
+	files, err := g.Diff(repoPath, "bar-0.1.0", "charts/foo")
+	require.NoError(t, err)
+	require.Empty(t, files)
+
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "charts/foo/values.yaml"), []byte("replicas: 2"), 0644))
+	files, err = g.Diff(repoPath, "bar-0.1.0", "charts/foo")
+	require.NoError(t, err)
+	require.Equal(t, []string{"charts/foo/values.yaml"}, files)
+
+	files, err = g.Diff(repoPath, "foo-0.1.0", "charts")
+	require.NoError(t, err)
+	require.Equal(t, []string{"charts/bar/Chart.yaml", "charts/foo/values.yaml"}, files)
+}
@@ -16,7 +16,10 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -30,17 +33,23 @@ import (
 )
 
 type Release struct {
+	ID                   int64
 	Name                 string
 	Description          string
 	Assets               []*Asset
 	Commit               string
 	GenerateReleaseNotes bool
 	MakeLatest           string
+	Draft                bool
+	Prerelease           bool
+	CreatedAt            time.Time
 }
 
 type Asset struct {
+	ID   int64
 	Path string
 	URL  string
+	Size int64
 }
 
 // Client is the client for interacting with the GitHub API
@@ -93,16 +102,74 @@ func (c *Client) GetRelease(_ context.Context, tag string) (*Release, error) {
 	}
 
 	result := &Release{
-		Assets: []*Asset{},
+		ID:          release.GetID(),
+		Name:        release.GetTagName(),
+		Description: release.GetBody(),
+		Assets:      []*Asset{},
+		Draft:       release.GetDraft(),
+		Prerelease:  release.GetPrerelease(),
+		CreatedAt:   release.GetCreatedAt().Time,
 	}
 	for _, ass := range release.Assets {
-		asset := &Asset{*ass.Name, *ass.BrowserDownloadURL}
-		result.Assets = append(result.Assets, asset)
+		result.Assets = append(result.Assets, newAsset(ass))
 	}
 	return result, nil
 }
 
-// CreateRelease creates a new release object in the GitHub API
+// ListReleases pages through all releases of the repository
+func (c *Client) ListReleases(_ context.Context) ([]*Release, error) {
+	var result []*Release
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		releases, resp, err := c.Repositories.ListReleases(context.TODO(), c.owner, c.repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, release := range releases {
+			r := &Release{
+				ID:          release.GetID(),
+				Name:        release.GetTagName(),
+				Description: release.GetBody(),
+				Assets:      []*Asset{},
+				Draft:       release.GetDraft(),
+				Prerelease:  release.GetPrerelease(),
+				CreatedAt:   release.GetCreatedAt().Time,
+			}
+			for _, ass := range release.Assets {
+				r.Assets = append(r.Assets, newAsset(ass))
+			}
+			result = append(result, r)
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return result, nil
+}
+
+// DownloadReleaseAsset downloads the given release asset to the specified file
+func (c *Client) DownloadReleaseAsset(_ context.Context, asset *Asset, filename string) error {
+	return retry.Retry(3, 3*time.Second, func() error {
+		rc, _, err := c.Repositories.DownloadReleaseAsset(context.TODO(), c.owner, c.repo, asset.ID, http.DefaultClient)
+		if err != nil {
+			return fmt.Errorf("failed to download release asset: %s: %w", asset.Path, err)
+		}
+		defer rc.Close()
+
+		f, err := os.Create(filename)
+		if err != nil {
+			return fmt.Errorf("failed to create file: %w", err)
+		}
+		defer f.Close()
+
+		_, err = io.Copy(f, rc)
+		return err
+	})
+}
+
+// CreateRelease creates a new release object in the GitHub API. Draft releases
+// are published once all assets were uploaded and deleted if that fails.
 func (c *Client) CreateRelease(_ context.Context, input *Release) error {
 	req := &github.RepositoryRelease{
 		Name:                 &input.Name,
@@ -110,7 +177,11 @@ func (c *Client) CreateRelease(_ context.Context, input *Release) error {
 		TagName:              &input.Name,
 		TargetCommitish:      &input.Commit,
 		GenerateReleaseNotes: &input.GenerateReleaseNotes,
-		MakeLatest:           &input.MakeLatest,
+		Draft:                &input.Draft,
+		Prerelease:           &input.Prerelease,
+	}
+	if !input.Draft {
+		req.MakeLatest = &input.MakeLatest
 	}
 
 	release, _, err := c.Repositories.CreateRelease(context.TODO(), c.owner, c.repo, req)
@@ -119,13 +190,65 @@ func (c *Client) CreateRelease(_ context.Context, input *Release) error {
 	}
 
 	for _, asset := range input.Assets {
-		if err := c.uploadReleaseAsset(context.TODO(), *release.ID, asset.Path); err != nil {
-			return err
+		if err := c.UploadReleaseAsset(context.TODO(), *release.ID, asset.Path); err != nil {
+			return c.deleteDraftRelease(input, release, err)
+		}
+	}
+
+	if input.Draft {
+		publish := &github.RepositoryRelease{
+			Draft:      github.Bool(false),
+			MakeLatest: &input.MakeLatest,
+		}
+		if _, _, err := c.Repositories.EditRelease(context.TODO(), c.owner, c.repo, *release.ID, publish); err != nil {
+			return c.deleteDraftRelease(input, release, fmt.Errorf("failed to publish draft release: %w", err))
 		}
 	}
 	return nil
 }
 
+// deleteDraftRelease deletes the given release if it is a draft, so that a failed
+// release never becomes visible. The original error is returned.
+func (c *Client) deleteDraftRelease(input *Release, release *github.RepositoryRelease, err error) error {
+	if !input.Draft {
+		return err
+	}
+	if _, deleteErr := c.Repositories.DeleteRelease(context.TODO(), c.owner, c.repo, *release.ID); deleteErr != nil {
+		return errors.Join(err, fmt.Errorf("failed to delete draft release %s: %w", input.Name, deleteErr))
+	}
+	return err
+}
+
+// DeleteRelease deletes the release with the given tag along with the tag itself
+func (c *Client) DeleteRelease(_ context.Context, tag string) error {
+	release, _, err := c.Repositories.GetReleaseByTag(context.TODO(), c.owner, c.repo, tag)
+	if err != nil {
+		return err
+	}
+	if _, err := c.Repositories.DeleteRelease(context.TODO(), c.owner, c.repo, release.GetID()); err != nil {
+		return err
+	}
+	if _, err := c.Git.DeleteRef(context.TODO(), c.owner, c.repo, "tags/"+tag); err != nil {
+		return fmt.Errorf("failed to delete tag %s: %w", tag, err)
+	}
+	return nil
+}
+
+// UpdateRelease updates the body of the release with the ID of the given release
+func (c *Client) UpdateRelease(_ context.Context, input *Release) error {
+	update := &github.RepositoryRelease{
+		Body: &input.Description,
+	}
+	_, _, err := c.Repositories.EditRelease(context.TODO(), c.owner, c.repo, input.ID, update)
+	return err
+}
+
+// DeleteReleaseAsset deletes the release asset with the given ID
+func (c *Client) DeleteReleaseAsset(_ context.Context, assetID int64) error {
+	_, err := c.Repositories.DeleteReleaseAsset(context.TODO(), c.owner, c.repo, assetID)
+	return err
+}
+
 // CreatePullRequest creates a pull request in the repository specified by repoURL.
 // The return value is the pull request URL.
 func (c *Client) CreatePullRequest(owner string, repo string, message string, head string, base string) (string, error) {
@@ -148,8 +271,17 @@ func (c *Client) CreatePullRequest(owner string, repo string, message string, he
 	return *pullRequest.HTMLURL, nil
 }
 
-// UploadAsset uploads specified assets to a given release object
-func (c *Client) uploadReleaseAsset(_ context.Context, releaseID int64, filename string) error {
+func newAsset(ass *github.ReleaseAsset) *Asset {
+	return &Asset{
+		ID:   ass.GetID(),
+		Path: ass.GetName(),
+		URL:  ass.GetBrowserDownloadURL(),
+		Size: int64(ass.GetSize()),
+	}
+}
+
+// UploadReleaseAsset uploads the specified file as asset to a given release object
+func (c *Client) UploadReleaseAsset(_ context.Context, releaseID int64, filename string) error {
 	filename, err := filepath.Abs(filename)
 	if err != nil {
 		return fmt.Errorf("failed to get abs path: %w", err)
@@ -0,0 +1,111 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packager
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"helm.sh/helm/v3/pkg/chartutil"
+
+	"github.com/helm/chart-releaser/pkg/releasename"
+)
+
+// changedCharts returns the charts with changes since the configured git ref or,
+// with ChangedSinceRelease, since the latest release tag of the chart. Changes
+// are taken from the working tree, so uncommitted changes count as well. Charts
+// without a release are always included, as are charts depending on an included
+// chart through a file:// repository. The reason for including or skipping each
+// chart is printed.
+func (p *Packager) changedCharts(chartPaths []string) ([]string, error) {
+	var tags []string
+	if p.config.ChangedSinceRelease {
+		var err error
+		if tags, err = p.git.Tags(""); err != nil {
+			return nil, err
+		}
+	}
+
+	refs := make([]string, len(chartPaths))
+	reasons := make([]string, len(chartPaths))
+	for i, chartPath := range chartPaths {
+		ref := p.config.ChangedSince
+		if p.config.ChangedSinceRelease {
+			metadata, err := chartutil.LoadChartfile(filepath.Join(chartPath, chartutil.ChartfileName))
+			if err != nil {
+				return nil, err
+			}
+			ref, err = releasename.LatestTag(p.config.ReleaseNameTemplate, metadata, tags)
+			if err != nil {
+				return nil, err
+			}
+			if ref == "" {
+				reasons[i] = fmt.Sprintf("chart %s has no release yet", metadata.Name)
+				continue
+			}
+		}
+		refs[i] = ref
+
+		files, err := p.git.Diff("", ref, chartPath)
+		if err != nil {
+			return nil, err
+		}
+		if len(files) == 0 {
+			continue
+		}
+		commits, err := p.git.Log("", ref+"..HEAD", chartPath)
+		if err != nil {
+			return nil, err
+		}
+		if len(commits) == 0 {
+			reasons[i] = fmt.Sprintf("%d file(s) with uncommitted changes since %s", len(files), ref)
+		} else {
+			reasons[i] = fmt.Sprintf("%d commit(s) since %s, latest %q", len(commits), ref, commits[0].Subject)
+		}
+	}
+
+	deps, err := fileDependencies(chartPaths)
+	if err != nil {
+		return nil, err
+	}
+	// Include dependent charts until there are no more, following chains of
+	// dependencies
+	for found := true; found; {
+		found = false
+		for i := range chartPaths {
+			if reasons[i] != "" {
+				continue
+			}
+			for _, j := range deps[i] {
+				if reasons[j] != "" {
+					reasons[i] = fmt.Sprintf("dependency %s changed", chartPaths[j])
+					found = true
+					break
+				}
+			}
+		}
+	}
+
+	var changed []string
+	for i, chartPath := range chartPaths {
+		if reasons[i] == "" {
+			fmt.Printf("Skipping %s: no changes since %s\n", chartPath, refs[i])
+			continue
+		}
+		fmt.Printf("Including %s: %s\n", chartPath, reasons[i])
+		changed = append(changed, chartPath)
+	}
+	return changed, nil
+}
@@ -0,0 +1,141 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helm/chart-releaser/pkg/config"
+	"github.com/helm/chart-releaser/pkg/git"
+)
+
+type FakeGit struct {
+	mock.Mock
+	tags    []string
+	commits map[string][]git.Commit
+	files   map[string][]string
+}
+
+func (f *FakeGit) Tags(workingDir string) ([]string, error) {
+	f.Called(workingDir)
+	return f.tags, nil
+}
+
+func (f *FakeGit) Log(workingDir string, revisionRange string, paths ...string) ([]git.Commit, error) {
+	f.Called(workingDir, revisionRange, paths)
+	return f.commits[revisionRange+" "+paths[0]], nil
+}
+
+func (f *FakeGit) Diff(workingDir string, ref string, paths ...string) ([]string, error) {
+	f.Called(workingDir, ref, paths)
+	return f.files[ref+" "+paths[0]], nil
+}
+
+func TestPackager_changedCharts(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"a-chart", "b-chart", "c-chart"} {
+		dir := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		chartfile := "apiVersion: v2\nname: " + name + "\nversion: 1.1.0\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "Chart.yaml"), []byte(chartfile), 0644))
+	}
+	chartPaths := []string{filepath.Join(root, "a-chart"), filepath.Join(root, "b-chart"), filepath.Join(root, "c-chart")}
+	change := []git.Commit{{Hash: "5e239bd", Subject: "Add ingress class name"}}
+
+	tests := []struct {
+		name     string
+		options  *config.Options
+		commits  map[string][]git.Commit
+		files    map[string][]string
+		expected []string
+	}{
+		{
+			name:    "changed since ref",
+			options: &config.Options{ChangedSince: "origin/main"},
+			commits: map[string][]git.Commit{
+				"origin/main..HEAD " + chartPaths[1]: change,
+			},
+			files: map[string][]string{
+				"origin/main " + chartPaths[1]: {"b-chart/values.yaml"},
+			},
+			expected: []string{chartPaths[1]},
+		},
+		{
+			name:    "uncommitted changes",
+			options: &config.Options{ChangedSince: "origin/main"},
+			files: map[string][]string{
+				"origin/main " + chartPaths[0]: {"a-chart/templates/service.yaml"},
+			},
+			expected: []string{chartPaths[0]},
+		},
+		{
+			name:    "changed since release",
+			options: &config.Options{ChangedSinceRelease: true, ReleaseNameTemplate: "{{ .Name }}-{{ .Version }}"},
+			commits: map[string][]git.Commit{
+				"a-chart-1.0.0..HEAD " + chartPaths[0]: change,
+				"b-chart-1.1.0..HEAD " + chartPaths[1]: nil,
+			},
+			files: map[string][]string{
+				"a-chart-1.0.0 " + chartPaths[0]: {"a-chart/values.yaml"},
+			},
+			// c-chart has no release yet
+			expected: []string{chartPaths[0], chartPaths[2]},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGit := new(FakeGit)
+			fakeGit.tags = []string{"a-chart-0.9.0", "a-chart-1.0.0", "b-chart-1.1.0", "other-chart-2.0.0"}
+			fakeGit.commits = tt.commits
+			fakeGit.files = tt.files
+			fakeGit.On("Tags", mock.Anything).Return(nil)
+			fakeGit.On("Log", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			fakeGit.On("Diff", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			p := NewPackager(tt.options, chartPaths, fakeGit)
+			changed, err := p.changedCharts(chartPaths)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, changed)
+		})
+	}
+}
+
+func TestPackager_changedChartsDependencies(t *testing.T) {
+	dir := t.TempDir()
+	common := writeChart(t, dir, "common")
+	library := writeChart(t, dir, "library", "common")
+	app := writeChart(t, dir, "app", "library")
+	standalone := writeChart(t, dir, "standalone")
+	chartPaths := []string{app, common, library, standalone}
+
+	fakeGit := new(FakeGit)
+	fakeGit.files = map[string][]string{
+		"origin/main " + common: {"common/values.yaml"},
+	}
+	fakeGit.On("Log", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	fakeGit.On("Diff", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	p := NewPackager(&config.Options{ChangedSince: "origin/main"}, chartPaths, fakeGit)
+	changed, err := p.changedCharts(chartPaths)
+	require.NoError(t, err)
+	// app depends on common through library
+	assert.Equal(t, []string{app, common, library}, changed)
+}
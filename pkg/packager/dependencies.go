@@ -0,0 +1,136 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packager
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+// packageOrder groups the charts into levels which are packaged one after the
+// other. Each chart comes after the charts it depends on through a file://
+// repository, so that the dependency is complete when it is copied into the
+// dependent chart. Dependencies outside of the charts being packaged are used as
+// they are. The charts of a level are independent of each other.
+func packageOrder(chartPaths []string) ([][]string, error) {
+	deps, err := fileDependencies(chartPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	var levels [][]string
+	done := make([]bool, len(chartPaths))
+	for remaining := len(chartPaths); remaining > 0; {
+		var ready []int
+		for i := range chartPaths {
+			if !done[i] && dependenciesDone(deps[i], done) {
+				ready = append(ready, i)
+			}
+		}
+		if len(ready) == 0 {
+			return nil, fmt.Errorf("charts have cyclic dependencies: %s", formatCycle(chartPaths, findCycle(deps, done)))
+		}
+
+		level := make([]string, 0, len(ready))
+		for _, i := range ready {
+			done[i] = true
+			level = append(level, chartPaths[i])
+		}
+		levels = append(levels, level)
+		remaining -= len(ready)
+	}
+	return levels, nil
+}
+
+// fileDependencies returns for each chart the indexes of the charts it depends
+// on through a file:// repository. Dependencies outside of the given charts are
+// left out.
+func fileDependencies(chartPaths []string) ([][]int, error) {
+	index := map[string]int{}
+	for i, chartPath := range chartPaths {
+		path, err := filepath.Abs(chartPath)
+		if err != nil {
+			return nil, err
+		}
+		index[path] = i
+	}
+
+	deps := make([][]int, len(chartPaths))
+	for i, chartPath := range chartPaths {
+		metadata, err := chartutil.LoadChartfile(filepath.Join(chartPath, chartutil.ChartfileName))
+		if err != nil {
+			return nil, err
+		}
+		for _, dep := range metadata.Dependencies {
+			if !strings.HasPrefix(dep.Repository, "file://") {
+				continue
+			}
+			path, err := filepath.Abs(filepath.Join(chartPath, strings.TrimPrefix(dep.Repository, "file://")))
+			if err != nil {
+				return nil, err
+			}
+			if j, ok := index[path]; ok {
+				deps[i] = append(deps[i], j)
+			}
+		}
+	}
+	return deps, nil
+}
+
+func dependenciesDone(deps []int, done []bool) bool {
+	for _, j := range deps {
+		if !done[j] {
+			return false
+		}
+	}
+	return true
+}
+
+// findCycle returns a cycle among the charts not done yet. Each of them depends
+// on another chart not done yet, so following these dependencies must end up in
+// a cycle.
+func findCycle(deps [][]int, done []bool) []int {
+	start := 0
+	for done[start] {
+		start++
+	}
+
+	seen := map[int]int{}
+	var walk []int
+	for i := start; ; {
+		if pos, ok := seen[i]; ok {
+			return append(walk[pos:], i)
+		}
+		seen[i] = len(walk)
+		walk = append(walk, i)
+		for _, j := range deps[i] {
+			if !done[j] {
+				i = j
+				break
+			}
+		}
+	}
+}
+
+func formatCycle(chartPaths []string, cycle []int) string {
+	names := make([]string, 0, len(cycle))
+	for _, i := range cycle {
+		names = append(names, chartPaths[i])
+	}
+	return strings.Join(names, " -> ")
+}
@@ -0,0 +1,104 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packager
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart/loader"
+
+	"github.com/helm/chart-releaser/pkg/config"
+)
+
+// writeChart writes a chart to dir/name depending on the given charts through
+// file:// repositories pointing at their sibling directories.
+func writeChart(t *testing.T, dir, name string, deps ...string) string {
+	t.Helper()
+	chartPath := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Join(chartPath, "templates"), 0755))
+
+	chartfile := fmt.Sprintf("apiVersion: v2\nname: %s\nversion: 0.1.0\n", name)
+	if len(deps) > 0 {
+		chartfile += "dependencies:\n"
+		for _, dep := range deps {
+			chartfile += fmt.Sprintf("  - name: %s\n    version: 0.1.0\n    repository: file://../%s\n", dep, dep)
+		}
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(chartPath, "Chart.yaml"), []byte(chartfile), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(chartPath, "templates", "configmap.yaml"), []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: "+name+"\n"), 0644))
+	return chartPath
+}
+
+func TestPackageOrder(t *testing.T) {
+	dir := t.TempDir()
+	app := writeChart(t, dir, "app", "library", "common")
+	library := writeChart(t, dir, "library", "common")
+	common := writeChart(t, dir, "common")
+	remote := writeChart(t, dir, "remote", "outside")
+	single := writeChart(t, dir, "single")
+
+	levels, err := packageOrder([]string{app, library, common, remote, single})
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{common, remote, single}, {library}, {app}}, levels)
+}
+
+func TestPackageOrder_Cycle(t *testing.T) {
+	dir := t.TempDir()
+	independent := writeChart(t, dir, "independent")
+	a := writeChart(t, dir, "a", "b")
+	b := writeChart(t, dir, "b", "c")
+	c := writeChart(t, dir, "c", "a")
+
+	_, err := packageOrder([]string{independent, a, b, c})
+	require.Error(t, err)
+	assert.Equal(t, fmt.Sprintf("charts have cyclic dependencies: %s", strings.Join([]string{a, b, c, a}, " -> ")), err.Error())
+
+	self := writeChart(t, dir, "self", "self")
+	_, err = packageOrder([]string{self})
+	assert.EqualError(t, err, fmt.Sprintf("charts have cyclic dependencies: %s -> %s", self, self))
+}
+
+func TestPackager_CreatePackages_Dependencies(t *testing.T) {
+	dir := t.TempDir()
+	packagePath := t.TempDir()
+	app := writeChart(t, dir, "app", "library")
+	library := writeChart(t, dir, "library", "common")
+	writeChart(t, dir, "common")
+
+	p := &Packager{
+		paths:  []string{dir},
+		config: &config.Options{PackagePath: packagePath, Concurrency: 2},
+	}
+	require.NoError(t, p.CreatePackages())
+	for _, name := range []string{"app", "library", "common"} {
+		assert.FileExists(t, filepath.Join(packagePath, name+"-0.1.0.tgz"))
+	}
+	assert.FileExists(t, filepath.Join(library, "charts", "common-0.1.0.tgz"))
+
+	// The library was complete with its own dependency when copied into the app
+	chrt, err := loader.Load(filepath.Join(packagePath, "app-0.1.0.tgz"))
+	require.NoError(t, err)
+	require.Len(t, chrt.Dependencies(), 1)
+	assert.Equal(t, "library", chrt.Dependencies()[0].Name())
+	require.Len(t, chrt.Dependencies()[0].Dependencies(), 1)
+	assert.Equal(t, "common", chrt.Dependencies()[0].Dependencies()[0].Name())
+	assert.FileExists(t, filepath.Join(app, "charts", "library-0.1.0.tgz"))
+}
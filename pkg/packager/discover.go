@@ -0,0 +1,133 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packager
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+// discoverCharts returns the chart directories for the configured paths. A path
+// holding a Chart.yaml is a chart itself. Any other path is a root searched
+// recursively for charts matching the include and exclude patterns. Subcharts
+// vendored in the charts directory of a chart are skipped.
+func (p *Packager) discoverCharts() ([]string, error) {
+	var chartPaths []string
+	for _, root := range p.paths {
+		if _, err := os.Stat(root); err != nil {
+			return nil, err
+		}
+		if isChartDir(root) {
+			chartPaths = append(chartPaths, root)
+			continue
+		}
+
+		found, err := p.findCharts(root)
+		if err != nil {
+			return nil, err
+		}
+		if len(found) == 0 {
+			fmt.Printf("No charts found in %s\n", root)
+		}
+		chartPaths = append(chartPaths, found...)
+	}
+	if len(chartPaths) == 0 {
+		return nil, errors.New("no charts found")
+	}
+	return chartPaths, nil
+}
+
+// findCharts walks the root and returns all chart directories matching the
+// include and exclude patterns.
+func (p *Packager) findCharts(root string) ([]string, error) {
+	var chartPaths []string
+	err := filepath.WalkDir(root, func(dir string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if dir != root && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if d.Name() == "charts" && isChartDir(filepath.Dir(dir)) {
+			// Vendored subcharts of the parent chart
+			return filepath.SkipDir
+		}
+		if !isChartDir(dir) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, dir)
+		if err != nil {
+			return err
+		}
+		selected, err := p.selectChart(filepath.ToSlash(rel))
+		if err != nil {
+			return err
+		}
+		if selected {
+			chartPaths = append(chartPaths, dir)
+		}
+		return nil
+	})
+	return chartPaths, err
+}
+
+// selectChart reports whether the chart directory at the given path relative to
+// its root matches any include pattern, if there are any, and no exclude
+// pattern.
+func (p *Packager) selectChart(rel string) (bool, error) {
+	if len(p.config.Include) > 0 {
+		included, err := matchAny(p.config.Include, rel)
+		if err != nil || !included {
+			return false, err
+		}
+	}
+	excluded, err := matchAny(p.config.Exclude, rel)
+	return !excluded, err
+}
+
+// matchAny reports whether the slash separated path matches any of the glob
+// patterns. Patterns without a slash are matched against the last element only.
+func matchAny(patterns []string, rel string) (bool, error) {
+	for _, pattern := range patterns {
+		name := rel
+		if !strings.Contains(pattern, "/") {
+			name = path.Base(rel)
+		}
+		matched, err := path.Match(pattern, name)
+		if err != nil {
+			return false, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func isChartDir(path string) bool {
+	stat, err := os.Stat(filepath.Join(path, chartutil.ChartfileName))
+	return err == nil && !stat.IsDir()
+}
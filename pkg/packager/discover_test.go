@@ -0,0 +1,108 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helm/chart-releaser/pkg/config"
+)
+
+func TestPackager_discoverCharts(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"charts/a-chart/charts/sub-chart", "charts/b-chart", "charts/docs", "incubator/c-chart", "incubator/legacy-chart", ".github/d-chart"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, dir), 0755))
+	}
+	for _, dir := range []string{"charts/a-chart", "charts/a-chart/charts/sub-chart", "charts/b-chart", "incubator/c-chart", "incubator/legacy-chart", ".github/d-chart"} {
+		require.NoError(t, os.WriteFile(filepath.Join(root, dir, "Chart.yaml"), []byte("name: chart\n"), 0644))
+	}
+
+	tests := []struct {
+		name     string
+		paths    []string
+		include  []string
+		exclude  []string
+		expected []string
+		error    bool
+	}{
+		{
+			name:     "chart directory",
+			paths:    []string{"testdata/test-chart"},
+			expected: []string{"testdata/test-chart"},
+		},
+		{
+			name:  "charts root",
+			paths: []string{filepath.Join(root, "charts"), "testdata/test-chart"},
+			expected: []string{
+				filepath.Join(root, "charts/a-chart"),
+				filepath.Join(root, "charts/b-chart"),
+				"testdata/test-chart",
+			},
+		},
+		{
+			name:  "recursive",
+			paths: []string{root},
+			expected: []string{
+				filepath.Join(root, "charts/a-chart"),
+				filepath.Join(root, "charts/b-chart"),
+				filepath.Join(root, "incubator/c-chart"),
+				filepath.Join(root, "incubator/legacy-chart"),
+			},
+		},
+		{
+			name:    "include and exclude",
+			paths:   []string{root},
+			include: []string{"incubator/*", "a-chart"},
+			exclude: []string{"legacy-*"},
+			expected: []string{
+				filepath.Join(root, "charts/a-chart"),
+				filepath.Join(root, "incubator/c-chart"),
+			},
+		},
+		{
+			name:    "invalid pattern",
+			paths:   []string{root},
+			exclude: []string{"["},
+			error:   true,
+		},
+		{
+			name:  "no charts",
+			paths: []string{filepath.Join(root, "charts/docs")},
+			error: true,
+		},
+		{
+			name:  "missing path",
+			paths: []string{"testdata/invalid-chart"},
+			error: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Packager{config: &config.Options{Include: tt.include, Exclude: tt.exclude}, paths: tt.paths}
+			chartPaths, err := p.discoverCharts()
+			if tt.error {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, chartPaths)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packager
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/lint/support"
+)
+
+// lintChart lints the chart and renders its templates like a dry run install
+// once for every values file matching the lint values patterns, or once with
+// the default values if there is none. It returns an error listing all
+// problems found.
+func (p *Packager) lintChart(path string) error {
+	valuesFiles, err := p.lintValuesFiles(path)
+	if err != nil {
+		return err
+	}
+	if len(valuesFiles) == 0 {
+		// Lint with the default values of the chart only
+		valuesFiles = []string{""}
+	}
+
+	var problems []string
+	for _, valuesFile := range valuesFiles {
+		vals := map[string]interface{}{}
+		prefix := "default values"
+		if valuesFile != "" {
+			prefix = valuesFile
+			values, err := chartutil.ReadValuesFile(valuesFile)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %s", prefix, err))
+				continue
+			}
+			vals = values.AsMap()
+		}
+
+		for _, problem := range lintProblems(path, vals) {
+			problems = append(problems, fmt.Sprintf("%s: %s", prefix, problem))
+		}
+		if err := renderChart(path, vals); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", prefix, err))
+		}
+	}
+	if len(problems) == 0 {
+		fmt.Printf("Successfully linted chart in %s\n", path)
+		return nil
+	}
+
+	// Printed at once to keep the problems of concurrently linted charts apart
+	fmt.Printf("Failed to lint chart in %s:\n  - %s\n", path, strings.Join(problems, "\n  - "))
+	return fmt.Errorf("chart in %s failed linting with %d problem(s)", path, len(problems))
+}
+
+// lintValuesFiles returns the values files matching the lint values patterns,
+// which are relative to the chart directory.
+func (p *Packager) lintValuesFiles(path string) ([]string, error) {
+	var valuesFiles []string
+	for _, pattern := range p.config.LintValues {
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(path, pattern)
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid lint values pattern %q: %w", pattern, err)
+		}
+		valuesFiles = append(valuesFiles, matches...)
+	}
+	return valuesFiles, nil
+}
+
+// lintProblems returns the errors found by helm lint. Template errors include
+// the file and line.
+func lintProblems(path string, vals map[string]interface{}) []string {
+	result := action.NewLint().Run([]string{path}, vals)
+
+	var problems []string
+	for _, msg := range result.Messages {
+		if msg.Severity >= support.ErrorSev {
+			problems = append(problems, msg.Error())
+		}
+	}
+	if len(problems) == 0 {
+		// The chart could not be loaded at all
+		for _, err := range result.Errors {
+			problems = append(problems, err.Error())
+		}
+	}
+	return problems
+}
+
+// renderChart renders the templates of the chart the way a client-only dry run
+// install does, which also validates the values against the schema and parses
+// the rendered manifests. Library charts are not installable and skipped.
+func renderChart(path string, vals map[string]interface{}) error {
+	chrt, err := loader.Load(path)
+	if err != nil {
+		return err
+	}
+	if chrt.Metadata.Type == "library" {
+		return nil
+	}
+
+	client := action.NewInstall(&action.Configuration{Log: func(string, ...interface{}) {}})
+	client.DryRun = true
+	client.ClientOnly = true
+	client.ReleaseName = "release-name"
+	client.Namespace = "default"
+	_, err = client.Run(chrt, vals)
+	return err
+}
@@ -0,0 +1,122 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helm/chart-releaser/pkg/config"
+)
+
+const requiredTemplate = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Release.Name }}
+data:
+  image: {{ required "image is required" .Values.image }}
+`
+
+func TestPackager_lintChart(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string]string
+		error  bool
+	}{
+		{
+			name: "default values",
+		},
+		{
+			name:   "valid values",
+			values: map[string]string{"ci/a-values.yaml": "image: nginx\n", "ci/b-values.yaml": "image: redis\n"},
+		},
+		{
+			name:   "render failure",
+			values: map[string]string{"ci/a-values.yaml": "image: nginx\n", "ci/b-values.yaml": "other: value\n"},
+			error:  true,
+		},
+		{
+			name:   "invalid values file",
+			values: map[string]string{"ci/a-values.yaml": "image: [\n"},
+			error:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chartPath := writeChart(t, t.TempDir(), "test-chart")
+			template := requiredTemplate
+			if tt.values == nil {
+				template = "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ .Release.Name }}\n"
+			}
+			require.NoError(t, os.WriteFile(filepath.Join(chartPath, "templates", "configmap.yaml"), []byte(template), 0644))
+			require.NoError(t, os.MkdirAll(filepath.Join(chartPath, "ci"), 0755))
+			for name, content := range tt.values {
+				require.NoError(t, os.WriteFile(filepath.Join(chartPath, name), []byte(content), 0644))
+			}
+
+			p := &Packager{config: &config.Options{LintValues: []string{"ci/*-values.yaml"}}}
+			err := p.lintChart(chartPath)
+			if tt.error {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLintProblems(t *testing.T) {
+	chartPath := writeChart(t, t.TempDir(), "test-chart")
+	require.NoError(t, os.WriteFile(filepath.Join(chartPath, "templates", "configmap.yaml"), []byte("metadata:\n  name: {{ .Release.Name\n"), 0644))
+
+	problems := lintProblems(chartPath, map[string]interface{}{})
+	require.NotEmpty(t, problems)
+	assert.Contains(t, problems[0], "templates/configmap.yaml:2")
+}
+
+func TestRenderChart(t *testing.T) {
+	chartPath := writeChart(t, t.TempDir(), "test-chart")
+	require.NoError(t, os.WriteFile(filepath.Join(chartPath, "templates", "configmap.yaml"), []byte(requiredTemplate), 0644))
+
+	err := renderChart(chartPath, map[string]interface{}{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "templates/configmap.yaml:6")
+	assert.Contains(t, err.Error(), "image is required")
+
+	require.NoError(t, renderChart(chartPath, map[string]interface{}{"image": "nginx"}))
+}
+
+func TestPackager_CreatePackages_Lint(t *testing.T) {
+	dir := t.TempDir()
+	packagePath := t.TempDir()
+	chartPath := writeChart(t, dir, "test-chart")
+	require.NoError(t, os.WriteFile(filepath.Join(chartPath, "templates", "configmap.yaml"), []byte(requiredTemplate), 0644))
+
+	p := &Packager{
+		paths:  []string{chartPath},
+		config: &config.Options{PackagePath: packagePath, Lint: true, LintValues: []string{"ci/*-values.yaml"}},
+	}
+	require.Error(t, p.CreatePackages())
+	assert.NoFileExists(t, filepath.Join(packagePath, "test-chart-0.1.0.tgz"))
+
+	require.NoError(t, os.MkdirAll(filepath.Join(chartPath, "ci"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(chartPath, "ci", "nginx-values.yaml"), []byte("image: nginx\n"), 0644))
+	require.NoError(t, p.CreatePackages())
+	assert.FileExists(t, filepath.Join(packagePath, "test-chart-0.1.0.tgz"))
+}
@@ -17,7 +17,6 @@ package packager
 import (
 	"fmt"
 	"io"
-	"os"
 	"path/filepath"
 	"strings"
 
@@ -25,28 +24,66 @@ import (
 	"helm.sh/helm/v3/pkg/downloader"
 	"helm.sh/helm/v3/pkg/getter"
 
+	"github.com/helm/chart-releaser/internal/concurrent"
 	"github.com/helm/chart-releaser/pkg/config"
+	"github.com/helm/chart-releaser/pkg/git"
 	"github.com/mitchellh/go-homedir"
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/registry"
 )
 
+// Git contains the git functions necessary for finding changed charts
+type Git interface {
+	Tags(workingDir string) ([]string, error)
+	Log(workingDir string, revisionRange string, paths ...string) ([]git.Commit, error)
+	Diff(workingDir string, ref string, paths ...string) ([]string, error)
+}
+
 // Packager exposes the packager object
 type Packager struct {
 	config *config.Options
 	paths  []string
+	git    Git
 }
 
 // NewPackager returns a configured Packager
-func NewPackager(config *config.Options, paths []string) *Packager {
+func NewPackager(config *config.Options, paths []string, git Git) *Packager {
 	return &Packager{
 		config: config,
 		paths:  paths,
+		git:    git,
 	}
 }
 
 // CreatePackages creates Helm chart packages
 func (p *Packager) CreatePackages() error {
+	chartPaths, err := p.discoverCharts()
+	if err != nil {
+		return err
+	}
+	if p.config.ChangedSince != "" || p.config.ChangedSinceRelease {
+		chartPaths, err = p.changedCharts(chartPaths)
+		if err != nil {
+			return err
+		}
+		if len(chartPaths) == 0 {
+			fmt.Println("No charts changed")
+			return nil
+		}
+	}
+	levels, err := packageOrder(chartPaths)
+	if err != nil {
+		return err
+	}
+	if p.config.List {
+		for _, level := range levels {
+			for _, chartPath := range level {
+				fmt.Println(chartPath)
+			}
+		}
+		return nil
+	}
+
 	helmClient := action.NewPackage()
 	helmClient.DependencyUpdate = true
 	helmClient.Destination = p.config.PackagePath
@@ -66,6 +103,11 @@ func (p *Packager) CreatePackages() error {
 		helmClient.Keyring = p.config.KeyRing
 		helmClient.PassphraseFile = p.config.PassphraseFile
 	}
+	// Reproducible packages are rewritten after packaging and signed afterwards
+	sign := helmClient.Sign
+	if p.config.Reproducible {
+		helmClient.Sign = false
+	}
 
 	settings := cli.New()
 	getters := getter.All(settings)
@@ -74,35 +116,58 @@ func (p *Packager) CreatePackages() error {
 		return err
 	}
 
-	for _, chartPath := range p.paths {
-		path, err := filepath.Abs(chartPath)
-		if err != nil {
-			return err
-		}
-		if _, err := os.Stat(chartPath); err != nil {
-			return err
-		}
+	// A chart is packaged only once the charts it depends on through file://
+	// repositories are, the charts of a level concurrently.
+	for _, level := range levels {
+		if err := concurrent.Run(len(level), p.config.Concurrency, func(i int) error {
+			path, err := filepath.Abs(level[i])
+			if err != nil {
+				return err
+			}
 
-		downloadManager := &downloader.Manager{
-			Out:              io.Discard,
-			ChartPath:        path,
-			Keyring:          helmClient.Keyring,
-			Getters:          getters,
-			Debug:            settings.Debug,
-			RepositoryConfig: settings.RepositoryConfig,
-			RepositoryCache:  settings.RepositoryCache,
-			RegistryClient:   registryClient,
-		}
-		if err := downloadManager.Build(); err != nil {
-			return err
-		}
-		packageRun, err := helmClient.Run(path, nil)
-		if err != nil {
-			fmt.Printf("Failed to package chart in %s (%s)\n", path, err.Error())
+			downloadManager := &downloader.Manager{
+				Out:              io.Discard,
+				ChartPath:        path,
+				Keyring:          helmClient.Keyring,
+				Getters:          getters,
+				Debug:            settings.Debug,
+				RepositoryConfig: settings.RepositoryConfig,
+				RepositoryCache:  settings.RepositoryCache,
+				RegistryClient:   registryClient,
+			}
+			if err := downloadManager.Build(); err != nil {
+				return err
+			}
+			if p.config.Lint {
+				if err := p.lintChart(path); err != nil {
+					return err
+				}
+			}
+			packageRun, err := helmClient.Run(path, nil)
+			if err != nil {
+				fmt.Printf("Failed to package chart in %s (%s)\n", path, err.Error())
+				return err
+			}
+			if p.config.Reproducible {
+				modTime, err := p.sourceDateEpoch(level[i])
+				if err != nil {
+					return err
+				}
+				if err := rewriteReproducible(packageRun, modTime); err != nil {
+					return fmt.Errorf("failed to make package %s reproducible: %w", packageRun, err)
+				}
+				if sign {
+					if err := helmClient.Clearsign(packageRun); err != nil {
+						return err
+					}
+				}
+			}
+
+			fmt.Printf("Successfully packaged chart in %s and saved it to: %s\n", path, packageRun)
+			return nil
+		}); err != nil {
 			return err
 		}
-
-		fmt.Printf("Successfully packaged chart in %s and saved it to: %s\n", path, packageRun)
 	}
 	return nil
 }
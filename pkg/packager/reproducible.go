@@ -0,0 +1,137 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packager
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sourceDateEpoch returns the timestamp of the files in a reproducible package
+// of the chart. It is taken from the SOURCE_DATE_EPOCH environment variable if
+// set, otherwise from the last commit touching the chart.
+func (p *Packager) sourceDateEpoch(chartPath string) (time.Time, error) {
+	if epoch, ok := os.LookupEnv("SOURCE_DATE_EPOCH"); ok {
+		seconds, err := strconv.ParseInt(epoch, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid SOURCE_DATE_EPOCH %q: %w", epoch, err)
+		}
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+
+	commits, err := p.git.Log("", "HEAD", chartPath)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(commits) == 0 {
+		return time.Time{}, fmt.Errorf("no commit found for chart in %s, set SOURCE_DATE_EPOCH instead", chartPath)
+	}
+	return commits[0].Time.UTC(), nil
+}
+
+type tarEntry struct {
+	header  *tar.Header
+	content []byte
+}
+
+// rewriteReproducible rewrites the chart package so that its bytes only depend
+// on the chart files.
+func rewriteReproducible(packagePath string, modTime time.Time) error {
+	data, err := os.ReadFile(packagePath)
+	if err != nil {
+		return err
+	}
+	data, err = reproducibleArchive(data, modTime)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(packagePath, data, 0644)
+}
+
+// reproducibleArchive returns the chart archive with its entries sorted by name,
+// their timestamps set to the given time and their ownership cleared. The gzip
+// header keeps the Helm marker but gets the given time as well. Archives of
+// dependencies in the charts directory are rewritten the same way, since they
+// are packaged from file:// repositories along with the chart.
+func reproducibleArchive(data []byte, modTime time.Time) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	var entries []tarEntry
+	tr := tar.NewReader(zr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		if path.Base(path.Dir(header.Name)) == "charts" && strings.HasSuffix(header.Name, ".tgz") {
+			if content, err = reproducibleArchive(content, modTime); err != nil {
+				return nil, fmt.Errorf("%s: %w", header.Name, err)
+			}
+		}
+		entries = append(entries, tarEntry{header: header, content: content})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].header.Name < entries[j].header.Name
+	})
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Header.Extra = zr.Header.Extra
+	zw.Header.Comment = zr.Header.Comment
+	zw.Header.ModTime = modTime
+	tw := tar.NewWriter(zw)
+	for _, entry := range entries {
+		header := &tar.Header{
+			Typeflag: entry.header.Typeflag,
+			Name:     entry.header.Name,
+			Linkname: entry.header.Linkname,
+			Mode:     entry.header.Mode,
+			Size:     int64(len(entry.content)),
+			ModTime:  modTime,
+			Format:   tar.FormatPAX,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(entry.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
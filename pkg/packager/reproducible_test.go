@@ -0,0 +1,171 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packager
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/provenance"
+
+	"github.com/helm/chart-releaser/pkg/config"
+	"github.com/helm/chart-releaser/pkg/git"
+)
+
+// writeArchive returns a gzipped tar archive of the given files in the given
+// order, stamped with the given time and owner.
+func writeArchive(t *testing.T, files [][2]string, modTime time.Time, owner int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Header.Comment = "Helm"
+	zw.Header.ModTime = modTime
+	tw := tar.NewWriter(zw)
+	for _, file := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:    file[0],
+			Mode:    0644,
+			Size:    int64(len(file[1])),
+			ModTime: modTime,
+			Uid:     owner,
+			Gid:     owner,
+			Uname:   "user",
+		}))
+		_, err := tw.Write([]byte(file[1]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestReproducibleArchive(t *testing.T) {
+	modTime := time.Unix(1700000000, 0).UTC()
+	archive := func(stamp time.Time, owner int, reversed bool) []byte {
+		dep := writeArchive(t, [][2]string{{"dep/Chart.yaml", "name: dep\n"}}, stamp, owner)
+		files := [][2]string{
+			{"app/Chart.yaml", "name: app\n"},
+			{"app/charts/dep-0.1.0.tgz", string(dep)},
+			{"app/values.yaml", "image: nginx\n"},
+		}
+		if reversed {
+			files[0], files[2] = files[2], files[0]
+		}
+		return writeArchive(t, files, stamp, owner)
+	}
+
+	first, err := reproducibleArchive(archive(time.Now(), 1000, false), modTime)
+	require.NoError(t, err)
+	second, err := reproducibleArchive(archive(time.Now().Add(time.Hour), 0, true), modTime)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+
+	zr, err := gzip.NewReader(bytes.NewReader(first))
+	require.NoError(t, err)
+	assert.Equal(t, "Helm", zr.Header.Comment)
+	assert.True(t, modTime.Equal(zr.Header.ModTime))
+
+	var names []string
+	tr := tar.NewReader(zr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		names = append(names, header.Name)
+		assert.True(t, modTime.Equal(header.ModTime))
+		assert.Equal(t, 0, header.Uid)
+		assert.Equal(t, "", header.Uname)
+	}
+	assert.Equal(t, []string{"app/Chart.yaml", "app/charts/dep-0.1.0.tgz", "app/values.yaml"}, names)
+
+	_, err = reproducibleArchive([]byte("not an archive"), modTime)
+	assert.Error(t, err)
+}
+
+func TestPackager_sourceDateEpoch(t *testing.T) {
+	commitTime := time.Unix(1700000000, 0)
+	fakeGit := &FakeGit{commits: map[string][]git.Commit{
+		"HEAD charts/app": {{Hash: "5e239bd", Time: commitTime}, {Hash: "a1b2c3d", Time: commitTime.Add(-time.Hour)}},
+	}}
+	fakeGit.On("Log", mock.Anything, mock.Anything, mock.Anything)
+	p := &Packager{config: &config.Options{}, git: fakeGit}
+
+	t.Setenv("SOURCE_DATE_EPOCH", "1600000000")
+	modTime, err := p.sourceDateEpoch("charts/app")
+	require.NoError(t, err)
+	assert.Equal(t, time.Unix(1600000000, 0).UTC(), modTime)
+
+	t.Setenv("SOURCE_DATE_EPOCH", "yesterday")
+	_, err = p.sourceDateEpoch("charts/app")
+	assert.ErrorContains(t, err, "invalid SOURCE_DATE_EPOCH")
+
+	require.NoError(t, os.Unsetenv("SOURCE_DATE_EPOCH"))
+	modTime, err = p.sourceDateEpoch("charts/app")
+	require.NoError(t, err)
+	assert.Equal(t, commitTime.UTC(), modTime)
+
+	_, err = p.sourceDateEpoch("charts/new")
+	assert.ErrorContains(t, err, "set SOURCE_DATE_EPOCH instead")
+}
+
+func TestPackager_CreatePackages_Reproducible(t *testing.T) {
+	t.Setenv("SOURCE_DATE_EPOCH", "1700000000")
+	dir := t.TempDir()
+	writeChart(t, dir, "app", "library")
+	writeChart(t, dir, "library")
+
+	var digests []string
+	for range 2 {
+		packagePath := t.TempDir()
+		p := &Packager{
+			paths: []string{dir},
+			config: &config.Options{
+				PackagePath:    packagePath,
+				Reproducible:   true,
+				Sign:           true,
+				Key:            "Chart Releaser Test Key <no-reply@example.com>",
+				KeyRing:        "testdata/testkeyring.gpg",
+				PassphraseFile: "testdata/passphrase-file.txt",
+			},
+		}
+		require.NoError(t, p.CreatePackages())
+
+		packageFile := filepath.Join(packagePath, "app-0.1.0.tgz")
+		digest, err := provenance.DigestFile(packageFile)
+		require.NoError(t, err)
+		digests = append(digests, digest)
+
+		// The provenance matches the rewritten package
+		signatory, err := provenance.NewFromKeyring("testdata/testkeyring.gpg", "")
+		require.NoError(t, err)
+		_, err = signatory.Verify(packageFile, packageFile+".prov")
+		require.NoError(t, err)
+
+		// Let the timestamps helm writes differ between both runs
+		time.Sleep(time.Second)
+	}
+	assert.Equal(t, digests[0], digests[1])
+}
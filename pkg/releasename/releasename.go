@@ -0,0 +1,130 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package releasename computes release names from the release name template
+// and recognizes the release tags of charts by reversing it.
+package releasename
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/semver/v3"
+	"helm.sh/helm/v3/pkg/chart"
+)
+
+// versionPlaceholder and namePlaceholder replace the chart version and name when
+// reversing the release name template
+const (
+	versionPlaceholder = "CHART_RELEASER_VERSION"
+	namePlaceholder    = "CHART_RELEASER_NAME"
+)
+
+// versionPattern matches a semantic version
+const versionPattern = `(?P<version>[0-9]+\.[0-9]+\.[0-9]+(?:-[0-9A-Za-z.-]+)?(?:\+[0-9A-Za-z.-]+)?)`
+
+// namePattern matches a chart name
+const namePattern = `(?P<name>[0-9A-Za-z_.-]+?)`
+
+// Render renders the release name template with the chart metadata.
+func Render(releaseNameTemplate string, metadata *chart.Metadata) (string, error) {
+	tmpl, err := template.New("gotpl").Parse(releaseNameTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, metadata); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+// Regexp reverses the release name template. The returned regexp matches the
+// release names of all versions of the given chart and captures the version.
+func Regexp(releaseNameTemplate string, metadata *chart.Metadata) (*regexp.Regexp, error) {
+	return reverse(releaseNameTemplate, metadata, false)
+}
+
+// AnyRegexp reverses the release name template like Regexp, but matches the
+// release names of all charts and captures name and version. Other chart
+// metadata used in the template is taken from the given chart.
+func AnyRegexp(releaseNameTemplate string, metadata *chart.Metadata) (*regexp.Regexp, error) {
+	return reverse(releaseNameTemplate, metadata, true)
+}
+
+func reverse(releaseNameTemplate string, metadata *chart.Metadata, anyName bool) (*regexp.Regexp, error) {
+	m := *metadata
+	m.Version = versionPlaceholder
+	if anyName {
+		m.Name = namePlaceholder
+	}
+	releaseName, err := Render(releaseNameTemplate, &m)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.Contains(releaseName, versionPlaceholder) {
+		return nil, fmt.Errorf("release name template %q does not contain the chart version, release tags cannot be recognized", releaseNameTemplate)
+	}
+
+	pattern := strings.ReplaceAll(regexp.QuoteMeta(releaseName), versionPlaceholder, versionPattern)
+	pattern = strings.ReplaceAll(pattern, namePlaceholder, namePattern)
+	return regexp.Compile("^" + pattern + "$")
+}
+
+// Version returns the version captured by a regexp returned by Regexp or
+// AnyRegexp, or nil if the release name does not match.
+func Version(nameRegexp *regexp.Regexp, releaseName string) *semver.Version {
+	index := nameRegexp.SubexpIndex("version")
+	match := nameRegexp.FindStringSubmatch(releaseName)
+	if match == nil || index < 0 {
+		return nil
+	}
+	v, err := semver.NewVersion(match[index])
+	if err != nil {
+		return nil
+	}
+	return v
+}
+
+// HighestTag returns the tag matching tagRegexp with the highest version,
+// considering only versions below the given one unless it is nil.
+func HighestTag(tagRegexp *regexp.Regexp, tags []string, below *semver.Version) string {
+	var highestTag string
+	var highest *semver.Version
+	for _, tag := range tags {
+		v := Version(tagRegexp, tag)
+		if v == nil || (below != nil && !v.LessThan(below)) {
+			continue
+		}
+		if highest == nil || v.GreaterThan(highest) {
+			highest = v
+			highestTag = tag
+		}
+	}
+	return highestTag
+}
+
+// LatestTag returns the tag of the highest release of the given chart among
+// tags. It returns an empty string if the chart has no release.
+func LatestTag(releaseNameTemplate string, metadata *chart.Metadata, tags []string) (string, error) {
+	tagRegexp, err := Regexp(releaseNameTemplate, metadata)
+	if err != nil {
+		return "", err
+	}
+	return HighestTag(tagRegexp, tags, nil), nil
+}
@@ -0,0 +1,88 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releasename
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart"
+)
+
+func TestRender(t *testing.T) {
+	name, err := Render("{{ .Name }}-v{{ .Version }}", &chart.Metadata{Name: "test-chart", Version: "1.0.0"})
+	require.NoError(t, err)
+	assert.Equal(t, "test-chart-v1.0.0", name)
+
+	_, err = Render("{{ .Name", &chart.Metadata{Name: "test-chart"})
+	assert.Error(t, err)
+}
+
+func TestAnyRegexp(t *testing.T) {
+	tests := []struct {
+		template string
+		release  string
+		name     string
+		version  string
+	}{
+		{template: "{{ .Name }}-{{ .Version }}", release: "test-chart-1.2.0", name: "test-chart", version: "1.2.0"},
+		{template: "{{ .Name }}-{{ .Version }}", release: "test-chart-extra-1.2.0-rc.1", name: "test-chart-extra", version: "1.2.0-rc.1"},
+		{template: "{{ .Name }}/v{{ .Version }}", release: "other-chart/v2.0.0", name: "other-chart", version: "2.0.0"},
+		{template: "{{ .Name }}/{{ .Name }}-{{ .Version }}", release: "other-chart/other-chart-2.0.0", name: "other-chart", version: "2.0.0"},
+		{template: "{{ .Name }}-{{ .Version }}", release: "not-a-release"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.release, func(t *testing.T) {
+			nameRegexp, err := AnyRegexp(tt.template, &chart.Metadata{Name: "test-chart", Version: "0.1.0"})
+			require.NoError(t, err)
+
+			match := nameRegexp.FindStringSubmatch(tt.release)
+			if tt.name == "" {
+				assert.Nil(t, match)
+				return
+			}
+			require.NotNil(t, match)
+			assert.Equal(t, tt.name, match[nameRegexp.SubexpIndex("name")])
+			assert.Equal(t, tt.version, Version(nameRegexp, tt.release).Original())
+		})
+	}
+}
+
+func TestLatestTag(t *testing.T) {
+	tags := []string{"test-chart-0.9.0", "test-chart-1.1.0", "test-chart-1.2.0-rc.1", "other-chart-2.0.0"}
+
+	tag, err := LatestTag("{{ .Name }}-{{ .Version }}", &chart.Metadata{Name: "test-chart", Version: "1.0.0"}, tags)
+	require.NoError(t, err)
+	assert.Equal(t, "test-chart-1.2.0-rc.1", tag)
+
+	tag, err = LatestTag("{{ .Name }}-{{ .Version }}", &chart.Metadata{Name: "new-chart", Version: "0.1.0"}, tags)
+	require.NoError(t, err)
+	assert.Equal(t, "", tag)
+}
+
+func TestRegexpWithoutVersion(t *testing.T) {
+	metadata := &chart.Metadata{Name: "test-chart", Version: "1.0.0", AppVersion: "2.0.0"}
+
+	_, err := LatestTag("platform-{{ .AppVersion }}", metadata, []string{"platform-2.0.0"})
+	assert.ErrorContains(t, err, "does not contain the chart version")
+
+	_, err = AnyRegexp("platform-{{ .AppVersion }}", metadata)
+	assert.ErrorContains(t, err, "does not contain the chart version")
+
+	nameRegexp := regexp.MustCompile("^platform-.*$")
+	assert.Nil(t, Version(nameRegexp, "platform-2.0.0"))
+}
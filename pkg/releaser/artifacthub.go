@@ -0,0 +1,132 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releaser
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"sigs.k8s.io/yaml"
+)
+
+const artifactHubChangesAnnotation = "artifacthub.io/changes"
+
+// artifactHubKinds holds the change kinds supported by Artifact Hub with their
+// headings in the order they are rendered
+var artifactHubKinds = []struct {
+	kind    string
+	heading string
+}{
+	{"added", "Added"},
+	{"changed", "Changed"},
+	{"deprecated", "Deprecated"},
+	{"removed", "Removed"},
+	{"fixed", "Fixed"},
+	{"security", "Security"},
+}
+
+// artifactHubChange is an entry of the artifacthub.io/changes annotation. The
+// annotation either holds a list of plain strings or a list of structured
+// entries with kind, description and links.
+type artifactHubChange struct {
+	Kind        string            `json:"kind"`
+	Description string            `json:"description"`
+	Links       []artifactHubLink `json:"links"`
+}
+
+type artifactHubLink struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+func (c *artifactHubChange) UnmarshalJSON(data []byte) error {
+	var description string
+	if err := json.Unmarshal(data, &description); err == nil {
+		*c = artifactHubChange{Description: description}
+		return nil
+	}
+	type change artifactHubChange
+	return json.Unmarshal(data, (*change)(c))
+}
+
+// renderArtifactHubChanges renders the artifacthub.io/changes annotation of the
+// chart as markdown. It returns an empty string if the chart has no changes.
+func renderArtifactHubChanges(metadata *chart.Metadata) (string, error) {
+	annotation := strings.TrimSpace(metadata.Annotations[artifactHubChangesAnnotation])
+	if annotation == "" {
+		return "", nil
+	}
+
+	var changes []artifactHubChange
+	if err := yaml.Unmarshal([]byte(annotation), &changes); err != nil {
+		return "", fmt.Errorf("failed to parse %s annotation of chart %s: %w", artifactHubChangesAnnotation, metadata.Name, err)
+	}
+	if len(changes) == 0 {
+		return "", nil
+	}
+
+	var b strings.Builder
+	b.WriteString("## Changes\n")
+
+	grouped := map[string][]artifactHubChange{}
+	var unkinded []artifactHubChange
+	for _, change := range changes {
+		kind := strings.ToLower(change.Kind)
+		if !isArtifactHubKind(kind) {
+			unkinded = append(unkinded, change)
+			continue
+		}
+		grouped[kind] = append(grouped[kind], change)
+	}
+
+	if len(unkinded) > 0 {
+		b.WriteString("\n")
+		writeArtifactHubChanges(&b, unkinded)
+	}
+	for _, kind := range artifactHubKinds {
+		if len(grouped[kind.kind]) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n### %s\n\n", kind.heading)
+		writeArtifactHubChanges(&b, grouped[kind.kind])
+	}
+	return b.String(), nil
+}
+
+func writeArtifactHubChanges(b *strings.Builder, changes []artifactHubChange) {
+	for _, change := range changes {
+		b.WriteString("- ")
+		b.WriteString(change.Description)
+		links := make([]string, 0, len(change.Links))
+		for _, link := range change.Links {
+			links = append(links, fmt.Sprintf("[%s](%s)", link.Name, link.URL))
+		}
+		if len(links) > 0 {
+			fmt.Fprintf(b, " (%s)", strings.Join(links, ", "))
+		}
+		b.WriteString("\n")
+	}
+}
+
+func isArtifactHubKind(kind string) bool {
+	for _, k := range artifactHubKinds {
+		if k.kind == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releaser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart"
+
+	"github.com/helm/chart-releaser/pkg/config"
+)
+
+func TestRenderArtifactHubChanges(t *testing.T) {
+	tests := []struct {
+		name       string
+		annotation string
+		expected   string
+		error      bool
+	}{
+		{
+			name:       "no annotation",
+			annotation: "",
+			expected:   "",
+		},
+		{
+			name: "string list",
+			annotation: `- Add ingress class name
+- Fix service port name
+`,
+			expected: `## Changes
+
+- Add ingress class name
+- Fix service port name
+`,
+		},
+		{
+			name: "structured",
+			annotation: `- kind: fixed
+  description: Fix service port name
+  links:
+    - name: GitHub Issue
+      url: https://github.com/owner/repo/issues/1
+    - name: GitHub PR
+      url: https://github.com/owner/repo/pull/2
+- kind: added
+  description: Add ingress class name
+- kind: Security
+  description: Update redis to fix CVE-2023-1234
+`,
+			expected: `## Changes
+
+### Added
+
+- Add ingress class name
+
+### Fixed
+
+- Fix service port name ([GitHub Issue](https://github.com/owner/repo/issues/1), [GitHub PR](https://github.com/owner/repo/pull/2))
+
+### Security
+
+- Update redis to fix CVE-2023-1234
+`,
+		},
+		{
+			name:       "invalid",
+			annotation: "kind: added",
+			error:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata := &chart.Metadata{
+				Name:        "test-chart",
+				Annotations: map[string]string{artifactHubChangesAnnotation: tt.annotation},
+			}
+			changes, err := renderArtifactHubChanges(metadata)
+			if tt.error {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, changes)
+		})
+	}
+}
+
+func TestReleaser_ReleaseNotesWithArtifactHubChanges(t *testing.T) {
+	r := &Releaser{
+		config: &config.Options{
+			ReleaseNotesFile:   "RELEASE.md",
+			ArtifactHubChanges: true,
+		},
+	}
+	ch := &chart.Chart{
+		Metadata: &chart.Metadata{
+			Name:        "test-chart",
+			Version:     "1.2.0",
+			Description: "A Helm chart for Kubernetes",
+			Annotations: map[string]string{artifactHubChangesAnnotation: "- Add ingress class name\n"},
+		},
+		Files: []*chart.File{{Name: "RELEASE.md", Data: []byte("# Release notes\n\n")}},
+	}
+
+	notes, err := r.getReleaseNotes(ch)
+	require.NoError(t, err)
+	assert.Equal(t, "# Release notes\n\n## Changes\n\n- Add ingress class name\n", notes)
+
+	ch.Files = nil
+	notes, err = r.getReleaseNotes(ch)
+	require.NoError(t, err)
+	assert.Equal(t, "A Helm chart for Kubernetes\n\n## Changes\n\n- Add ingress class name\n", notes)
+}
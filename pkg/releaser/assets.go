@@ -0,0 +1,102 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releaser
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"helm.sh/helm/v3/pkg/chart"
+
+	"github.com/helm/chart-releaser/pkg/github"
+)
+
+// releaseAssets returns the assets of a release group: the chart packages, their
+// provenance files and the extra assets of the charts. Asset names must be unique
+// within a release.
+func (r *Releaser) releaseAssets(group *releaseGroup) ([]*github.Asset, error) {
+	var assets []*github.Asset
+	paths := map[string]string{}
+	add := func(path string) error {
+		name := filepath.Base(path)
+		if existing, ok := paths[name]; ok {
+			if filepath.Clean(existing) == filepath.Clean(path) {
+				return nil
+			}
+			return fmt.Errorf("release %s has several assets named %s: %s and %s", group.name, name, existing, path)
+		}
+		paths[name] = path
+		assets = append(assets, &github.Asset{Path: path})
+		return nil
+	}
+
+	for i, p := range group.packages {
+		if err := add(p); err != nil {
+			return nil, err
+		}
+		provFile := fmt.Sprintf("%s.prov", p)
+		if _, err := os.Stat(provFile); err == nil {
+			if err := add(provFile); err != nil {
+				return nil, err
+			}
+		}
+
+		extraAssets, err := r.extraAssets(group.charts[i].Metadata)
+		if err != nil {
+			return nil, err
+		}
+		for _, extraAsset := range extraAssets {
+			if err := add(extraAsset); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return assets, nil
+}
+
+// extraAssets returns the files matching the extra asset patterns for a chart.
+// The patterns are rendered as Go templates with the chart metadata before
+// globbing, e.g. "charts/{{ .Name }}/values.schema.json".
+func (r *Releaser) extraAssets(metadata *chart.Metadata) ([]string, error) {
+	var files []string
+	for _, pattern := range r.config.ExtraAssets {
+		tmpl, err := template.New("extra-asset").Parse(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid extra asset pattern %q: %w", pattern, err)
+		}
+		var buffer bytes.Buffer
+		if err := tmpl.Execute(&buffer, metadata); err != nil {
+			return nil, fmt.Errorf("invalid extra asset pattern %q: %w", pattern, err)
+		}
+
+		matches, err := filepath.Glob(buffer.String())
+		if err != nil {
+			return nil, fmt.Errorf("invalid extra asset pattern %q: %w", pattern, err)
+		}
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil {
+				return nil, err
+			}
+			if !info.IsDir() {
+				files = append(files, match)
+			}
+		}
+	}
+	return files, nil
+}
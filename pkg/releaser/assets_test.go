@@ -0,0 +1,116 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releaser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart"
+
+	"github.com/helm/chart-releaser/pkg/config"
+)
+
+func TestReleaser_ReleaseAssets(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []string{
+		"test-chart-0.1.0.tgz",
+		"test-chart-0.1.0.tgz.prov",
+		"sub-chart-0.1.0.tgz",
+		"charts/test-chart/values.schema.json",
+		"charts/test-chart/README.md",
+		"charts/sub-chart/README.md",
+		"sbom/test-chart-0.1.0.spdx.json",
+		"sbom/test-chart-0.0.1.spdx.json",
+	} {
+		path := filepath.Join(dir, f)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(f), 0644))
+	}
+	group := &releaseGroup{
+		name:     "test-chart-0.1.0",
+		packages: []string{filepath.Join(dir, "test-chart-0.1.0.tgz")},
+		charts:   []*chart.Chart{{Metadata: &chart.Metadata{Name: "test-chart", Version: "0.1.0"}}},
+	}
+
+	tests := []struct {
+		name        string
+		extraAssets []string
+		group       *releaseGroup
+		expected    []string
+		error       string
+	}{
+		{
+			name:     "no extra assets",
+			group:    group,
+			expected: []string{"test-chart-0.1.0.tgz", "test-chart-0.1.0.tgz.prov"},
+		},
+		{
+			name: "extra assets",
+			extraAssets: []string{
+				filepath.Join(dir, "charts/{{ .Name }}/*.json"),
+				filepath.Join(dir, "sbom/{{ .Name }}-{{ .Version }}.spdx.json"),
+				filepath.Join(dir, "missing/*"),
+			},
+			group: group,
+			expected: []string{
+				"test-chart-0.1.0.tgz",
+				"test-chart-0.1.0.tgz.prov",
+				"charts/test-chart/values.schema.json",
+				"sbom/test-chart-0.1.0.spdx.json",
+			},
+		},
+		{
+			name:        "duplicate asset names",
+			extraAssets: []string{filepath.Join(dir, "charts/{{ .Name }}/README.md")},
+			group: &releaseGroup{
+				name:     "charts-0.1.0",
+				packages: []string{filepath.Join(dir, "test-chart-0.1.0.tgz"), filepath.Join(dir, "sub-chart-0.1.0.tgz")},
+				charts: []*chart.Chart{
+					{Metadata: &chart.Metadata{Name: "test-chart", Version: "0.1.0"}},
+					{Metadata: &chart.Metadata{Name: "sub-chart", Version: "0.1.0"}},
+				},
+			},
+			error: "release charts-0.1.0 has several assets named README.md",
+		},
+		{
+			name:        "invalid template",
+			extraAssets: []string{"{{ .Name"},
+			group:       group,
+			error:       "invalid extra asset pattern",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Releaser{config: &config.Options{ExtraAssets: tt.extraAssets}}
+			assets, err := r.releaseAssets(tt.group)
+			if tt.error != "" {
+				require.ErrorContains(t, err, tt.error)
+				return
+			}
+			require.NoError(t, err)
+			var paths []string
+			for _, asset := range assets {
+				rel, err := filepath.Rel(dir, asset.Path)
+				require.NoError(t, err)
+				paths = append(paths, filepath.ToSlash(rel))
+			}
+			assert.Equal(t, tt.expected, paths)
+		})
+	}
+}
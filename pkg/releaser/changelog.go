@@ -0,0 +1,136 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releaser
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"helm.sh/helm/v3/pkg/chart"
+)
+
+const (
+	changelogFormatKeepAChangelog = "keepachangelog"
+	changelogFormatMarkdown       = "markdown"
+
+	changelogFallbackNotes = "notes"
+	changelogFallbackEmpty = "empty"
+	changelogFallbackError = "error"
+)
+
+// defaultChangelogHeadingPatterns holds the section heading pattern used for
+// each changelog format, unless a pattern is configured explicitly.
+var defaultChangelogHeadingPatterns = map[string]string{
+	// ## [1.2.3] - 2024-01-31
+	changelogFormatKeepAChangelog: `^##\s+\[v?{{ quoteMeta .Version }}\]`,
+	// any heading starting with the version, e.g. ### v1.2.3
+	changelogFormatMarkdown: `^#+\s+\[?v?{{ quoteMeta .Version }}\]?(\s|$)`,
+}
+
+// getChangelogSection returns the section of the changelog file in the chart
+// package matching the chart version. ok is false if there is no such section.
+func (r *Releaser) getChangelogSection(chart *chart.Chart) (section string, ok bool, err error) {
+	var changelog []byte
+	for _, f := range chart.Files {
+		if f.Name == r.config.ChangelogFile {
+			changelog = f.Data
+			break
+		}
+	}
+	if changelog == nil {
+		fmt.Printf("The changelog file %q, is not present in the chart package\n", r.config.ChangelogFile)
+		return "", false, nil
+	}
+
+	heading, err := r.changelogHeadingRegexp(chart.Metadata)
+	if err != nil {
+		return "", false, err
+	}
+
+	section, ok = extractMarkdownSection(string(changelog), heading)
+	if !ok {
+		fmt.Printf("The changelog file %q has no section for version %s\n", r.config.ChangelogFile, chart.Metadata.Version)
+	}
+	return section, ok, nil
+}
+
+func (r *Releaser) changelogHeadingRegexp(metadata *chart.Metadata) (*regexp.Regexp, error) {
+	pattern := r.config.ChangelogHeadingPattern
+	if pattern == "" {
+		format := r.config.ChangelogFormat
+		if format == "" {
+			format = changelogFormatKeepAChangelog
+		}
+		var ok bool
+		if pattern, ok = defaultChangelogHeadingPatterns[format]; !ok {
+			return nil, fmt.Errorf("unknown changelog format %q", format)
+		}
+	}
+
+	tmpl, err := template.New("changelog").Funcs(template.FuncMap{"quoteMeta": regexp.QuoteMeta}).Parse(pattern)
+	if err != nil {
+		return nil, err
+	}
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, metadata); err != nil {
+		return nil, err
+	}
+	return regexp.Compile(buffer.String())
+}
+
+// extractMarkdownSection returns the content below the first heading matching
+// the given pattern, up to the next heading of the same or a higher level.
+func extractMarkdownSection(markdown string, heading *regexp.Regexp) (string, bool) {
+	var section []string
+	level := 0
+	inCodeBlock := false
+	for _, line := range strings.Split(markdown, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCodeBlock = !inCodeBlock
+		}
+		lineLevel := 0
+		if !inCodeBlock {
+			lineLevel = headingLevel(line)
+		}
+
+		if level == 0 {
+			if lineLevel > 0 && heading.MatchString(line) {
+				level = lineLevel
+			}
+			continue
+		}
+		if lineLevel > 0 && lineLevel <= level {
+			break
+		}
+		section = append(section, line)
+	}
+	if level == 0 {
+		return "", false
+	}
+	return strings.TrimSpace(strings.Join(section, "\n")), true
+}
+
+// headingLevel returns the level of an ATX markdown heading or 0 if the line is
+// not a heading.
+func headingLevel(line string) int {
+	level := len(line) - len(strings.TrimLeft(line, "#"))
+	if level == 0 || level > 6 || (len(line) > level && line[level] != ' ' && line[level] != '\t') {
+		return 0
+	}
+	return level
+}
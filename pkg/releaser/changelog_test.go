@@ -0,0 +1,156 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releaser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart"
+
+	"github.com/helm/chart-releaser/pkg/config"
+)
+
+const testChangelog = `# Changelog
+
+All notable changes to this chart will be documented in this file.
+
+## [Unreleased]
+
+### Added
+
+- Something in progress
+
+## [1.2.0] - 2024-02-01
+
+### Added
+
+- Support for ingress class names
+
+` + "```yaml" + `
+# not a heading
+ingress: {}
+` + "```" + `
+
+### Fixed
+
+- Service port name
+
+## [1.1.0] - 2024-01-01
+
+### Added
+
+- Initial release
+
+[1.2.0]: https://github.com/owner/repo/compare/1.1.0...1.2.0
+`
+
+func TestReleaser_ReleaseNotesChangelog(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  string
+		config   *config.Options
+		expected string
+		error    bool
+	}{
+		{
+			name:    "keepachangelog-section",
+			version: "1.2.0",
+			config:  &config.Options{ChangelogFile: "CHANGELOG.md"},
+			expected: "### Added\n\n- Support for ingress class names\n\n```yaml\n# not a heading\ningress: {}\n```\n\n" +
+				"### Fixed\n\n- Service port name",
+		},
+		{
+			name:     "keepachangelog-last-section",
+			version:  "1.1.0",
+			config:   &config.Options{ChangelogFile: "CHANGELOG.md", ChangelogFormat: "keepachangelog"},
+			expected: "### Added\n\n- Initial release\n\n[1.2.0]: https://github.com/owner/repo/compare/1.1.0...1.2.0",
+		},
+		{
+			name:     "custom-heading-pattern",
+			version:  "1.2.0",
+			config:   &config.Options{ChangelogFile: "CHANGELOG.md", ChangelogHeadingPattern: `^### Fixed`},
+			expected: "- Service port name",
+		},
+		{
+			name:     "missing-section-falls-back-to-description",
+			version:  "1.3.0",
+			config:   &config.Options{ChangelogFile: "CHANGELOG.md"},
+			expected: "A Helm chart for Kubernetes",
+		},
+		{
+			name:     "missing-section-falls-back-to-release-notes-file",
+			version:  "1.3.0",
+			config:   &config.Options{ChangelogFile: "CHANGELOG.md", ReleaseNotesFile: "release-notes.md"},
+			expected: "The release notes file content is used as release notes",
+		},
+		{
+			name:     "missing-section-empty-fallback",
+			version:  "1.3.0",
+			config:   &config.Options{ChangelogFile: "CHANGELOG.md", ChangelogFallback: "empty"},
+			expected: "",
+		},
+		{
+			name:    "missing-section-error-fallback",
+			version: "1.3.0",
+			config:  &config.Options{ChangelogFile: "CHANGELOG.md", ChangelogFallback: "error"},
+			error:   true,
+		},
+		{
+			name:     "missing-changelog-file",
+			version:  "1.2.0",
+			config:   &config.Options{ChangelogFile: "HISTORY.md"},
+			expected: "A Helm chart for Kubernetes",
+		},
+		{
+			name:    "unknown-format",
+			version: "1.2.0",
+			config:  &config.Options{ChangelogFile: "CHANGELOG.md", ChangelogFormat: "asciidoc"},
+			error:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch := &chart.Chart{
+				Metadata: &chart.Metadata{
+					Name:        "test-chart",
+					Version:     tt.version,
+					Description: "A Helm chart for Kubernetes",
+				},
+				Files: []*chart.File{
+					{Name: "CHANGELOG.md", Data: []byte(testChangelog)},
+					{Name: "release-notes.md", Data: []byte("The release notes file content is used as release notes")},
+				},
+			}
+			r := &Releaser{config: tt.config}
+			notes, err := r.getReleaseNotes(ch)
+			if tt.error {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, notes)
+		})
+	}
+}
+
+func TestHeadingLevel(t *testing.T) {
+	assert.Equal(t, 1, headingLevel("# Changelog"))
+	assert.Equal(t, 3, headingLevel("### Added"))
+	assert.Equal(t, 0, headingLevel("#hashtag"))
+	assert.Equal(t, 0, headingLevel("####### too deep"))
+	assert.Equal(t, 0, headingLevel("- item"))
+}
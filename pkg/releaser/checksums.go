@@ -0,0 +1,198 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releaser
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"helm.sh/helm/v3/pkg/provenance"
+
+	"github.com/helm/chart-releaser/pkg/github"
+)
+
+const (
+	checksumsManifestName   = "SHA256SUMS"
+	checksumsManifestSuffix = ".sha256"
+)
+
+// writeChecksumsFile writes a checksum manifest listing the SHA256 digests of the
+// given assets to dir, in the format of sha256sum. Its name is rendered from the
+// checksums file template with the metadata of the first chart of the group.
+func (r *Releaser) writeChecksumsFile(group *releaseGroup, assets []*github.Asset, dir string) (*github.Asset, error) {
+	tmpl, err := template.New("checksums-file").Parse(r.config.ChecksumsFile)
+	if err != nil {
+		return nil, fmt.Errorf("invalid checksums file template: %w", err)
+	}
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, group.charts[0].Metadata); err != nil {
+		return nil, fmt.Errorf("invalid checksums file template: %w", err)
+	}
+	name := buffer.String()
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return nil, fmt.Errorf("invalid checksums file name %q", name)
+	}
+
+	var manifest strings.Builder
+	for _, asset := range assets {
+		if filepath.Base(asset.Path) == name {
+			return nil, fmt.Errorf("release %s has several assets named %s", group.name, name)
+		}
+		digest, err := provenance.DigestFile(asset.Path)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&manifest, "%s  %s\n", digest, filepath.Base(asset.Path))
+	}
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(manifest.String()), 0644); err != nil {
+		return nil, err
+	}
+	return &github.Asset{Path: path}, nil
+}
+
+// VerifyReleases downloads the assets of the releases with the given tags and
+// checks them against the checksum manifest of the release.
+func (r *Releaser) VerifyReleases(tags []string) error {
+	var errs []error
+	for _, tag := range tags {
+		fmt.Printf("Verifying release %s\n", tag)
+		if err := r.verifyRelease(tag); err != nil {
+			fmt.Printf("Release %s failed verification\n", tag)
+			errs = append(errs, fmt.Errorf("release %s: %w", tag, err))
+			continue
+		}
+		fmt.Printf("Release %s verified\n", tag)
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Releaser) verifyRelease(tag string) error {
+	release, err := r.github.GetRelease(context.TODO(), tag)
+	if err != nil {
+		return err
+	}
+	manifest, err := findChecksumsManifest(release.Assets)
+	if err != nil {
+		return err
+	}
+
+	dir, err := os.MkdirTemp("", "chart-releaser-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	manifestPath := filepath.Join(dir, filepath.Base(manifest.Path))
+	if err := r.github.DownloadReleaseAsset(context.TODO(), manifest, manifestPath); err != nil {
+		return err
+	}
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return err
+	}
+	checksums, err := parseChecksums(data)
+	if err != nil {
+		return fmt.Errorf("invalid checksum manifest %s: %w", filepath.Base(manifest.Path), err)
+	}
+
+	var errs []error
+	assets := map[string]bool{}
+	for _, asset := range release.Assets {
+		if asset == manifest {
+			continue
+		}
+		name := filepath.Base(asset.Path)
+		assets[name] = true
+
+		expected, ok := checksums[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("asset %s is not listed in %s", name, filepath.Base(manifest.Path)))
+			continue
+		}
+		path := filepath.Join(dir, name)
+		if err := r.github.DownloadReleaseAsset(context.TODO(), asset, path); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		digest, err := provenance.DigestFile(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if digest != expected {
+			errs = append(errs, fmt.Errorf("asset %s has digest %s, but %s lists %s", name, digest, filepath.Base(manifest.Path), expected))
+			continue
+		}
+		fmt.Printf("Verified %s\n", name)
+	}
+	for name := range checksums {
+		if !assets[name] {
+			errs = append(errs, fmt.Errorf("asset %s listed in %s is missing", name, filepath.Base(manifest.Path)))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// findChecksumsManifest returns the checksum manifest asset of a release, which
+// is either named SHA256SUMS or is the only asset with the .sha256 suffix.
+func findChecksumsManifest(assets []*github.Asset) (*github.Asset, error) {
+	var candidates []*github.Asset
+	for _, asset := range assets {
+		name := filepath.Base(asset.Path)
+		if name == checksumsManifestName {
+			return asset, nil
+		}
+		if strings.HasSuffix(name, checksumsManifestSuffix) {
+			candidates = append(candidates, asset)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		return nil, fmt.Errorf("no checksum manifest found")
+	case 1:
+		return candidates[0], nil
+	default:
+		return nil, fmt.Errorf("found %d checksum manifests with suffix %s", len(candidates), checksumsManifestSuffix)
+	}
+}
+
+// parseChecksums parses a manifest in the format of sha256sum into a map of file
+// names to digests.
+func parseChecksums(data []byte) (map[string]string, error) {
+	checksums := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		digest, name, ok := strings.Cut(line, " ")
+		if !ok || len(digest) != 64 {
+			return nil, fmt.Errorf("invalid line %q", line)
+		}
+		name = strings.TrimPrefix(strings.TrimLeft(name, " "), "*")
+		checksums[name] = strings.ToLower(digest)
+	}
+	return checksums, scanner.Err()
+}
@@ -0,0 +1,205 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releaser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/provenance"
+
+	"github.com/helm/chart-releaser/pkg/config"
+	"github.com/helm/chart-releaser/pkg/github"
+)
+
+var checksumsTestAssets = []*github.Asset{
+	{Path: "testdata/release-packages/test-chart-0.1.0.tgz"},
+	{Path: "testdata/release-packages/third-party-file-0.1.0.txt"},
+}
+
+func TestReleaser_WriteChecksumsFile(t *testing.T) {
+	group := &releaseGroup{
+		name:   "test-chart-0.1.0",
+		charts: []*chart.Chart{{Metadata: &chart.Metadata{Name: "test-chart", Version: "0.1.0"}}},
+	}
+	chartDigest, err := provenance.DigestFile("testdata/release-packages/test-chart-0.1.0.tgz")
+	require.NoError(t, err)
+	fileDigest, err := provenance.DigestFile("testdata/release-packages/third-party-file-0.1.0.txt")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		checksumsFile string
+		expectedName  string
+		error         bool
+	}{
+		{
+			name:          "fixed name",
+			checksumsFile: "SHA256SUMS",
+			expectedName:  "SHA256SUMS",
+		},
+		{
+			name:          "templated name",
+			checksumsFile: "{{ .Name }}-{{ .Version }}.sha256",
+			expectedName:  "test-chart-0.1.0.sha256",
+		},
+		{
+			name:          "name of an asset",
+			checksumsFile: "test-chart-0.1.0.tgz",
+			error:         true,
+		},
+		{
+			name:          "path",
+			checksumsFile: "{{ .Name }}/SHA256SUMS",
+			error:         true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			r := &Releaser{config: &config.Options{ChecksumsFile: tt.checksumsFile}}
+			asset, err := r.writeChecksumsFile(group, checksumsTestAssets, dir)
+			if tt.error {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Join(dir, tt.expectedName), asset.Path)
+
+			data, err := os.ReadFile(asset.Path)
+			require.NoError(t, err)
+			expected := chartDigest + "  test-chart-0.1.0.tgz\n" + fileDigest + "  third-party-file-0.1.0.txt\n"
+			assert.Equal(t, expected, string(data))
+		})
+	}
+}
+
+func TestReleaser_VerifyReleases(t *testing.T) {
+	group := &releaseGroup{
+		name:   "test-chart-0.1.0",
+		charts: []*chart.Chart{{Metadata: &chart.Metadata{Name: "test-chart", Version: "0.1.0"}}},
+	}
+
+	tests := []struct {
+		name     string
+		manifest func(t *testing.T, dir string) *github.Asset
+		error    string
+	}{
+		{
+			name: "valid manifest",
+			manifest: func(t *testing.T, dir string) *github.Asset {
+				r := &Releaser{config: &config.Options{ChecksumsFile: "SHA256SUMS"}}
+				asset, err := r.writeChecksumsFile(group, checksumsTestAssets, dir)
+				require.NoError(t, err)
+				return asset
+			},
+		},
+		{
+			name: "valid per chart manifest",
+			manifest: func(t *testing.T, dir string) *github.Asset {
+				r := &Releaser{config: &config.Options{ChecksumsFile: "{{ .Name }}-{{ .Version }}.sha256"}}
+				asset, err := r.writeChecksumsFile(group, checksumsTestAssets, dir)
+				require.NoError(t, err)
+				return asset
+			},
+		},
+		{
+			name: "digest mismatch",
+			manifest: func(t *testing.T, dir string) *github.Asset {
+				r := &Releaser{config: &config.Options{ChecksumsFile: "SHA256SUMS"}}
+				asset, err := r.writeChecksumsFile(group, checksumsTestAssets, dir)
+				require.NoError(t, err)
+				data, err := os.ReadFile(asset.Path)
+				require.NoError(t, err)
+				tampered := strings.Repeat("0", 64) + string(data[64:])
+				require.NoError(t, os.WriteFile(asset.Path, []byte(tampered), 0644))
+				return asset
+			},
+			error: "asset test-chart-0.1.0.tgz has digest",
+		},
+		{
+			name: "unlisted and missing assets",
+			manifest: func(t *testing.T, dir string) *github.Asset {
+				r := &Releaser{config: &config.Options{ChecksumsFile: "SHA256SUMS"}}
+				prov := filepath.Join(dir, "test-chart-0.1.0.tgz.prov")
+				require.NoError(t, os.WriteFile(prov, []byte("signature"), 0644))
+				assets := []*github.Asset{checksumsTestAssets[0], {Path: prov}}
+				asset, err := r.writeChecksumsFile(group, assets, dir)
+				require.NoError(t, err)
+				return asset
+			},
+			error: "asset third-party-file-0.1.0.txt is not listed in SHA256SUMS\nasset test-chart-0.1.0.tgz.prov listed in SHA256SUMS is missing",
+		},
+		{
+			name: "no manifest",
+			manifest: func(_ *testing.T, _ string) *github.Asset {
+				return nil
+			},
+			error: "release test-chart-0.1.0: no checksum manifest found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGitHub := new(FakeGitHub)
+			fakeGitHub.On("DownloadReleaseAsset", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			if asset := tt.manifest(t, t.TempDir()); asset != nil {
+				fakeGitHub.extraAssets = []*github.Asset{asset}
+			}
+			r := &Releaser{config: &config.Options{}, github: fakeGitHub}
+
+			err := r.VerifyReleases([]string{"test-chart-0.1.0"})
+			if tt.error != "" {
+				require.ErrorContains(t, err, tt.error)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestReleaser_CreateReleasesChecksums(t *testing.T) {
+	var manifest string
+	fakeGitHub := new(FakeGitHub)
+	fakeGitHub.On("CreateRelease", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		release := args.Get(1).(*github.Release)
+		data, err := os.ReadFile(release.Assets[len(release.Assets)-1].Path)
+		require.NoError(t, err)
+		manifest = string(data)
+	})
+	r := &Releaser{
+		config: &config.Options{
+			PackagePath:         "testdata/release-packages",
+			ReleaseNameTemplate: "{{ .Name }}-{{ .Version }}",
+			ChecksumsFile:       "{{ .Name }}-{{ .Version }}.sha256",
+		},
+		github: fakeGitHub,
+		git:    new(FakeGit),
+	}
+
+	require.NoError(t, r.CreateReleases())
+	require.Len(t, fakeGitHub.release.Assets, 2)
+	assert.Equal(t, "test-chart-0.1.0.sha256", filepath.Base(fakeGitHub.release.Assets[1].Path))
+	assert.NoFileExists(t, fakeGitHub.release.Assets[1].Path)
+
+	digest, err := provenance.DigestFile("testdata/release-packages/test-chart-0.1.0.tgz")
+	require.NoError(t, err)
+	assert.Equal(t, digest+"  test-chart-0.1.0.tgz\n", manifest)
+}
@@ -0,0 +1,127 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releaser
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/repo"
+)
+
+// DeleteChartVersion yanks a chart version: it removes the version from the index
+// file and, with packages hosted in the GitHub Pages branch, its package. The
+// index change is committed to the GitHub Pages branch like by UpdateIndexFile.
+// Finally the GitHub release of the version and its tag are deleted, but only
+// once the index no longer references them: after a direct push, or when the
+// index did not have the version. With --pr or without pushing the release is
+// kept and has to be deleted by running the command again afterwards.
+func (r *Releaser) DeleteChartVersion(name string, version string) error {
+	worktree, indexYamlPath, indexFile, err := r.loadIndexFile()
+	if err != nil {
+		return err
+	}
+	defer r.git.RemoveWorktree("", worktree) // nolint: errcheck
+
+	original := copyIndexFile(indexFile)
+	chartVersion := removeIndexEntry(indexFile, name, version)
+	removedEntry := chartVersion != nil
+	if removedEntry {
+		fmt.Printf("Removing %s-%s from index %s\n", name, version, r.config.IndexPath)
+	} else {
+		// The release name template may only use name and version then
+		chartVersion = &repo.ChartVersion{Metadata: &chart.Metadata{Name: name, Version: version}}
+	}
+
+	var removedPackages []string
+	if r.config.PackagesWithIndex {
+		pkg := fmt.Sprintf("%s-%s%s", name, version, chartAssetFileExtension)
+		for _, file := range []string{pkg, pkg + ".prov"} {
+			path := filepath.Join(worktree, file)
+			if _, err := os.Stat(path); err != nil {
+				continue
+			}
+			fmt.Printf("Removing %s from branch %q\n", file, r.config.PagesBranch)
+			if !r.config.DryRun {
+				if err := os.Remove(path); err != nil {
+					return err
+				}
+			}
+			removedPackages = append(removedPackages, path)
+		}
+	}
+
+	releaseName, err := r.computeReleaseName(&chart.Chart{Metadata: chartVersion.Metadata})
+	if err != nil {
+		return err
+	}
+	existingRelease, _ := r.github.GetRelease(context.TODO(), releaseName)
+
+	if !removedEntry && len(removedPackages) == 0 && existingRelease == nil {
+		return fmt.Errorf("chart %s version %s not found", name, version)
+	}
+
+	if removedEntry || len(removedPackages) > 0 {
+		indexFile.Generated = time.Now()
+		if r.config.DryRun {
+			r.Plan().addIndexChanges(original, indexFile)
+		} else if err := indexFile.WriteFile(r.config.IndexPath, 0644); err != nil {
+			return err
+		}
+
+		if r.config.Push || r.config.PR {
+			message := fmt.Sprintf("Delete %s %s", name, version)
+			if err := r.commitIndexFile(worktree, indexYamlPath, message, removedPackages...); err != nil {
+				return err
+			}
+		}
+	}
+
+	if existingRelease == nil {
+		fmt.Printf("Release %s not found\n", releaseName)
+		return nil
+	}
+	if (removedEntry || len(removedPackages) > 0) && !r.config.Push {
+		if r.config.PR {
+			fmt.Printf("Keeping release %s until the pull request is merged. Run 'cr delete %s %s' again afterwards to delete it\n", releaseName, name, version)
+		} else {
+			fmt.Printf("Keeping release %s as the index change is not pushed. Run 'cr delete %s %s --push' to push it and delete the release\n", releaseName, name, version)
+		}
+		return nil
+	}
+	return r.deleteReleases([]*repo.ChartVersion{chartVersion})
+}
+
+// removeIndexEntry removes the given chart version from the index file. It
+// returns the removed entry or nil if the index file does not have it.
+func removeIndexEntry(indexFile *repo.IndexFile, name string, version string) *repo.ChartVersion {
+	versions := indexFile.Entries[name]
+	for i, cv := range versions {
+		if cv.Version != version {
+			continue
+		}
+		if len(versions) == 1 {
+			delete(indexFile.Entries, name)
+		} else {
+			indexFile.Entries[name] = append(versions[:i:i], versions[i+1:]...)
+		}
+		return cv
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releaser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/repo"
+
+	"github.com/helm/chart-releaser/pkg/config"
+)
+
+func TestReleaser_DeleteChartVersion(t *testing.T) {
+	tests := []struct {
+		name              string
+		chart             string
+		version           string
+		packagesWithIndex bool
+		pr                bool
+		noPush            bool
+		dryRun            bool
+		removedEntry      bool
+	}{
+		{
+			name:         "indexed version",
+			chart:        "test-chart",
+			version:      "0.1.0",
+			removedEntry: true,
+		},
+		{
+			name:              "indexed version with packages",
+			chart:             "test-chart",
+			version:           "0.1.0",
+			packagesWithIndex: true,
+			removedEntry:      true,
+		},
+		{
+			name:         "pull request",
+			chart:        "test-chart",
+			version:      "0.1.0",
+			pr:           true,
+			removedEntry: true,
+		},
+		{
+			name:         "not pushed",
+			chart:        "test-chart",
+			version:      "0.1.0",
+			noPush:       true,
+			removedEntry: true,
+		},
+		{
+			name:         "dry run",
+			chart:        "test-chart",
+			version:      "0.1.0",
+			dryRun:       true,
+			removedEntry: true,
+		},
+		{
+			name:    "release only",
+			chart:   "test-chart",
+			version: "0.3.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indexPath := filepath.Join(t.TempDir(), "index.yaml")
+			fakeGitHub := new(FakeGitHub)
+			fakeGitHub.On("DeleteRelease", mock.Anything, mock.Anything).Return(nil)
+			fakeGitHub.On("CreatePullRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			fakeGit := new(FakeGit)
+			fakeGit.indexFile = "testdata/prune-repo/index.yaml"
+			fakeGit.pagesFiles = []string{"testdata/release-packages/test-chart-0.1.0.tgz"}
+			fakeGit.On("RemoveWorktree", mock.Anything, mock.Anything).Return(nil)
+			fakeGit.On("Pull", mock.Anything, mock.Anything).Return(nil)
+			fakeGit.On("Add", mock.Anything, mock.Anything).Return(nil)
+			fakeGit.On("Commit", mock.Anything, mock.Anything).Return(nil)
+			fakeGit.On("GetPushURL", mock.Anything, mock.Anything).Return(nil)
+			fakeGit.On("Push", mock.Anything, mock.Anything).Return(nil)
+			r := &Releaser{
+				config: &config.Options{
+					IndexPath:           indexPath,
+					PagesIndexPath:      "index.yaml",
+					PagesBranch:         "gh-pages",
+					ReleaseNameTemplate: "{{ .Name }}-{{ .Version }}",
+					PackagesWithIndex:   tt.packagesWithIndex,
+					Push:                !tt.pr && !tt.noPush,
+					PR:                  tt.pr,
+					DryRun:              tt.dryRun,
+				},
+				github: fakeGitHub,
+				git:    fakeGit,
+			}
+
+			err := r.DeleteChartVersion(tt.chart, tt.version)
+			require.NoError(t, err)
+
+			if tt.dryRun {
+				fakeGitHub.AssertNotCalled(t, "DeleteRelease", mock.Anything, mock.Anything)
+				fakeGit.AssertNotCalled(t, "Commit", mock.Anything, mock.Anything)
+				assert.Equal(t, []*PlannedEntry{{Name: "test-chart", Version: "0.1.0"}}, r.Plan().RemovedEntries)
+				assert.Equal(t, []string{"test-chart-0.1.0"}, r.Plan().DeletedReleases)
+				assert.Equal(t, []string{"Delete test-chart 0.1.0"}, r.Plan().Commits)
+				assert.NoFileExists(t, indexPath)
+				return
+			}
+
+			if tt.pr || tt.noPush {
+				fakeGitHub.AssertNotCalled(t, "DeleteRelease", mock.Anything, mock.Anything)
+			} else {
+				fakeGitHub.AssertCalled(t, "DeleteRelease", mock.Anything, tt.chart+"-"+tt.version)
+			}
+			if tt.noPush {
+				fakeGit.AssertNotCalled(t, "Commit", mock.Anything, mock.Anything)
+				return
+			}
+			if !tt.removedEntry {
+				fakeGit.AssertNotCalled(t, "Commit", mock.Anything, mock.Anything)
+				return
+			}
+			fakeGit.AssertCalled(t, "Commit", mock.Anything, "Delete test-chart 0.1.0")
+			indexFile, err := repo.LoadIndexFile(indexPath)
+			require.NoError(t, err)
+			assert.False(t, indexFile.Has("test-chart", "0.1.0"))
+			assert.True(t, indexFile.Has("test-chart", "0.2.0"))
+			assert.True(t, indexFile.Has("other-chart", "1.0.0"))
+
+			var paths []string
+			for _, call := range fakeGit.Calls {
+				if call.Method == "Add" {
+					paths = call.Arguments.Get(1).([]string)
+				}
+			}
+			if tt.packagesWithIndex {
+				require.Len(t, paths, 2)
+				assert.Equal(t, "test-chart-0.1.0.tgz", filepath.Base(paths[1]))
+				assert.NoFileExists(t, paths[1])
+			} else {
+				assert.Len(t, paths, 1)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releaser
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/repo"
+
+	"github.com/helm/chart-releaser/pkg/github"
+)
+
+// DeprecateChart marks the versions of a chart matching the configured version
+// range as deprecated in the index file and commits it to the GitHub Pages branch
+// like UpdateIndexFile. A deprecation note is added to the GitHub releases of
+// these versions.
+func (r *Releaser) DeprecateChart(name string) error {
+	var constraint *semver.Constraints
+	if r.config.VersionRange != "" {
+		c, err := semver.NewConstraint(r.config.VersionRange)
+		if err != nil {
+			return fmt.Errorf("invalid version range %q: %w", r.config.VersionRange, err)
+		}
+		constraint = c
+	}
+
+	worktree, indexYamlPath, indexFile, err := r.loadIndexFile()
+	if err != nil {
+		return err
+	}
+	defer r.git.RemoveWorktree("", worktree) // nolint: errcheck
+
+	matched, deprecated := deprecateIndexEntries(indexFile, name, constraint)
+	if len(matched) == 0 {
+		return fmt.Errorf("no versions of chart %s found in index %s matching %q", name, r.config.IndexPath, r.config.VersionRange)
+	}
+
+	if len(deprecated) > 0 {
+		for _, cv := range deprecated {
+			fmt.Printf("Deprecating %s-%s in index %s\n", cv.Name, cv.Version, r.config.IndexPath)
+		}
+		indexFile.Generated = time.Now()
+		if r.config.DryRun {
+			for _, cv := range deprecated {
+				r.Plan().DeprecatedEntries = append(r.Plan().DeprecatedEntries, &PlannedEntry{Name: cv.Name, Version: cv.Version})
+			}
+		} else if err := indexFile.WriteFile(r.config.IndexPath, 0644); err != nil {
+			return err
+		}
+
+		if r.config.Push || r.config.PR {
+			message := fmt.Sprintf("Deprecate %s", name)
+			if r.config.VersionRange != "" {
+				message = fmt.Sprintf("Deprecate %s %s", name, r.config.VersionRange)
+			}
+			if err := r.commitIndexFile(worktree, indexYamlPath, message); err != nil {
+				return err
+			}
+		}
+	} else {
+		fmt.Printf("Index %s did not change\n", r.config.IndexPath)
+	}
+
+	for _, cv := range matched {
+		if err := r.addDeprecationNote(cv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deprecateIndexEntries sets the deprecated flag on all versions of the chart in
+// the index file matching the constraint, or all versions if it is nil. It
+// returns the matching versions and those which were not yet deprecated.
+func deprecateIndexEntries(indexFile *repo.IndexFile, name string, constraint *semver.Constraints) ([]*repo.ChartVersion, []*repo.ChartVersion) {
+	var matched, deprecated []*repo.ChartVersion
+	for _, cv := range indexFile.Entries[name] {
+		if constraint != nil {
+			v, err := semver.NewVersion(cv.Version)
+			if err != nil || !constraint.Check(v) {
+				continue
+			}
+		}
+		matched = append(matched, cv)
+		if !cv.Deprecated {
+			cv.Deprecated = true
+			deprecated = append(deprecated, cv)
+		}
+	}
+	return matched, deprecated
+}
+
+// addDeprecationNote prepends the deprecation message to the body of the GitHub
+// release of the chart version unless it already has it.
+func (r *Releaser) addDeprecationNote(cv *repo.ChartVersion) error {
+	releaseName, err := r.computeReleaseName(&chart.Chart{Metadata: cv.Metadata})
+	if err != nil {
+		return err
+	}
+	release, _ := r.github.GetRelease(context.TODO(), releaseName)
+	if release == nil {
+		fmt.Printf("Release %s not found\n", releaseName)
+		return nil
+	}
+
+	note := fmt.Sprintf("> [!WARNING]\n> %s", r.config.DeprecationMessage)
+	if strings.Contains(release.Description, note) {
+		return nil
+	}
+	if r.config.DryRun {
+		r.Plan().UpdatedReleases = append(r.Plan().UpdatedReleases, releaseName)
+		return nil
+	}
+
+	fmt.Printf("Adding deprecation note to release %s\n", releaseName)
+	update := &github.Release{ID: release.ID, Name: release.Name, Description: note}
+	if release.Description != "" {
+		update.Description = note + "\n\n" + release.Description
+	}
+	if err := r.github.UpdateRelease(context.TODO(), update); err != nil {
+		return fmt.Errorf("error updating GitHub release %s: %w", releaseName, err)
+	}
+	return nil
+}
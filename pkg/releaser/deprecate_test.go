@@ -0,0 +1,145 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releaser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/repo"
+
+	"github.com/helm/chart-releaser/pkg/config"
+	"github.com/helm/chart-releaser/pkg/github"
+)
+
+func TestReleaser_DeprecateChart(t *testing.T) {
+	tests := []struct {
+		name         string
+		versionRange string
+		dryRun       bool
+		deprecated   []string
+		error        string
+	}{
+		{
+			name:       "all versions",
+			deprecated: []string{"0.2.0", "0.1.0"},
+		},
+		{
+			name:         "version range",
+			versionRange: "< 0.2.0",
+			deprecated:   []string{"0.1.0"},
+		},
+		{
+			name:         "dry run",
+			versionRange: "< 0.2.0",
+			dryRun:       true,
+			deprecated:   []string{"0.1.0"},
+		},
+		{
+			name:         "no matching versions",
+			versionRange: ">= 1.0.0",
+			error:        `no versions of chart test-chart found in index`,
+		},
+		{
+			name:         "invalid version range",
+			versionRange: "not a range",
+			error:        `invalid version range "not a range"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indexPath := filepath.Join(t.TempDir(), "index.yaml")
+			fakeGitHub := new(FakeGitHub)
+			fakeGitHub.On("UpdateRelease", mock.Anything, mock.Anything).Return(nil)
+			fakeGit := new(FakeGit)
+			fakeGit.indexFile = "testdata/prune-repo/index.yaml"
+			fakeGit.On("RemoveWorktree", mock.Anything, mock.Anything).Return(nil)
+			fakeGit.On("Pull", mock.Anything, mock.Anything).Return(nil)
+			fakeGit.On("Add", mock.Anything, mock.Anything).Return(nil)
+			fakeGit.On("Commit", mock.Anything, mock.Anything).Return(nil)
+			fakeGit.On("GetPushURL", mock.Anything, mock.Anything).Return(nil)
+			fakeGit.On("Push", mock.Anything, mock.Anything).Return(nil)
+			r := &Releaser{
+				config: &config.Options{
+					IndexPath:           indexPath,
+					PagesIndexPath:      "index.yaml",
+					PagesBranch:         "gh-pages",
+					ReleaseNameTemplate: "{{ .Name }}-{{ .Version }}",
+					VersionRange:        tt.versionRange,
+					DeprecationMessage:  "This chart is deprecated",
+					Push:                true,
+					DryRun:              tt.dryRun,
+				},
+				github: fakeGitHub,
+				git:    fakeGit,
+			}
+
+			err := r.DeprecateChart("test-chart")
+			if tt.error != "" {
+				require.ErrorContains(t, err, tt.error)
+				return
+			}
+			require.NoError(t, err)
+
+			if tt.dryRun {
+				fakeGitHub.AssertNotCalled(t, "UpdateRelease", mock.Anything, mock.Anything)
+				fakeGit.AssertNotCalled(t, "Commit", mock.Anything, mock.Anything)
+				assert.Equal(t, []*PlannedEntry{{Name: "test-chart", Version: "0.1.0"}}, r.Plan().DeprecatedEntries)
+				assert.Equal(t, []string{"test-chart-0.1.0"}, r.Plan().UpdatedReleases)
+				assert.Equal(t, []string{"Deprecate test-chart < 0.2.0"}, r.Plan().Commits)
+				return
+			}
+
+			indexFile, err := repo.LoadIndexFile(indexPath)
+			require.NoError(t, err)
+			var deprecated []string
+			for _, cv := range indexFile.Entries["test-chart"] {
+				if cv.Deprecated {
+					deprecated = append(deprecated, cv.Version)
+				}
+			}
+			assert.Equal(t, tt.deprecated, deprecated)
+			assert.False(t, indexFile.Entries["other-chart"][0].Deprecated)
+
+			fakeGit.AssertNumberOfCalls(t, "Commit", 1)
+			fakeGitHub.AssertNumberOfCalls(t, "UpdateRelease", len(tt.deprecated))
+			fakeGitHub.AssertCalled(t, "UpdateRelease", mock.Anything, mock.MatchedBy(func(release *github.Release) bool {
+				return release.Description == "> [!WARNING]\n> This chart is deprecated\n\nA Helm chart for Kubernetes"
+			}))
+		})
+	}
+}
+
+func TestDeprecateIndexEntries(t *testing.T) {
+	indexFile, err := repo.LoadIndexFile("testdata/prune-repo/index.yaml")
+	require.NoError(t, err)
+	indexFile.Entries["test-chart"][1].Deprecated = true
+
+	matched, deprecated := deprecateIndexEntries(indexFile, "test-chart", nil)
+	assert.Len(t, matched, 2)
+	require.Len(t, deprecated, 1)
+	assert.Equal(t, "0.2.0", deprecated[0].Version)
+
+	matched, deprecated = deprecateIndexEntries(indexFile, "test-chart", nil)
+	assert.Len(t, matched, 2)
+	assert.Empty(t, deprecated)
+
+	matched, deprecated = deprecateIndexEntries(indexFile, "missing-chart", nil)
+	assert.Empty(t, matched)
+	assert.Empty(t, deprecated)
+}
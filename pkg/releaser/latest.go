@@ -0,0 +1,125 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releaser
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+	"helm.sh/helm/v3/pkg/chart"
+
+	"github.com/helm/chart-releaser/pkg/releasename"
+)
+
+const (
+	latestPolicyAlways  = "always"
+	latestPolicyHighest = "highest"
+)
+
+// markLatestReleases decides which of the release groups are marked as latest
+// release. With the highest latest policy only the group with the highest
+// version among the existing releases and the groups is marked, considering
+// only the releases of the primary chart if one is configured.
+func (r *Releaser) markLatestReleases(groups []*releaseGroup) error {
+	for _, group := range groups {
+		group.latest = r.config.MakeReleaseLatest && !group.prerelease()
+	}
+
+	switch r.config.LatestPolicy {
+	case "", latestPolicyAlways:
+		return nil
+	case latestPolicyHighest:
+	default:
+		return fmt.Errorf("unknown latest policy %q", r.config.LatestPolicy)
+	}
+
+	var highest *semver.Version
+	var highestGroup *releaseGroup
+	for _, group := range groups {
+		_, v := r.latestCandidate(group)
+		if group.latest && v != nil && (highest == nil || v.GreaterThan(highest)) {
+			highest = v
+			highestGroup = group
+		}
+		group.latest = false
+	}
+	if highestGroup == nil {
+		return nil
+	}
+
+	metadata, _ := r.latestCandidate(highestGroup)
+	existing, err := r.highestExistingVersion(metadata)
+	if err != nil {
+		return err
+	}
+	if existing == nil || !highest.LessThan(existing) {
+		highestGroup.latest = true
+	}
+	return nil
+}
+
+// latestCandidate returns the metadata and version of the chart which decides
+// whether the group is marked as latest release, or nil if the group must not
+// be marked.
+func (r *Releaser) latestCandidate(group *releaseGroup) (*chart.Metadata, *semver.Version) {
+	var candidate *chart.Metadata
+	var highest *semver.Version
+	for _, ch := range group.charts {
+		if r.config.PrimaryChart != "" && ch.Metadata.Name != r.config.PrimaryChart {
+			continue
+		}
+		v, err := semver.NewVersion(ch.Metadata.Version)
+		if err != nil {
+			continue
+		}
+		if highest == nil || v.GreaterThan(highest) {
+			highest = v
+			candidate = ch.Metadata
+		}
+	}
+	return candidate, highest
+}
+
+// highestExistingVersion returns the highest non-prerelease version of the
+// existing published GitHub releases, either of all charts or of the primary chart.
+func (r *Releaser) highestExistingVersion(metadata *chart.Metadata) (*semver.Version, error) {
+	nameRegexp, err := releasename.AnyRegexp(r.config.ReleaseNameTemplate, metadata)
+	if r.config.PrimaryChart != "" {
+		nameRegexp, err = releasename.Regexp(r.config.ReleaseNameTemplate, metadata)
+	}
+	if err != nil {
+		return nil, err
+	}
+	releases, err := r.github.ListReleases(context.TODO())
+	if err != nil {
+		return nil, err
+	}
+
+	var highest *semver.Version
+	for _, release := range releases {
+		if release.Draft {
+			continue
+		}
+		v := releasename.Version(nameRegexp, release.Name)
+		if v == nil || v.Prerelease() != "" {
+			continue
+		}
+		if highest == nil || v.GreaterThan(highest) {
+			highest = v
+		}
+	}
+	return highest, nil
+}
@@ -0,0 +1,128 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releaser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart"
+
+	"github.com/helm/chart-releaser/pkg/config"
+	"github.com/helm/chart-releaser/pkg/github"
+)
+
+func TestReleaser_markLatestReleases(t *testing.T) {
+	existing := []*github.Release{
+		{Name: "test-chart-1.2.0"},
+		{Name: "test-chart-1.3.0-rc.1"},
+		{Name: "other-chart-2.0.0"},
+		{Name: "other-chart-9.0.0", Draft: true},
+		{Name: "unrelated-tag"},
+	}
+	tests := []struct {
+		name         string
+		policy       string
+		primaryChart string
+		makeLatest   bool
+		charts       [][2]string
+		expected     []bool
+	}{
+		{
+			name:       "always",
+			policy:     "always",
+			makeLatest: true,
+			charts:     [][2]string{{"test-chart", "1.1.1"}, {"other-chart", "1.0.0"}, {"test-chart", "1.4.0-rc.1"}},
+			expected:   []bool{true, true, false},
+		},
+		{
+			name:       "always without make latest",
+			policy:     "always",
+			makeLatest: false,
+			charts:     [][2]string{{"test-chart", "1.1.1"}},
+			expected:   []bool{false},
+		},
+		{
+			name:       "highest in batch",
+			policy:     "highest",
+			makeLatest: true,
+			charts:     [][2]string{{"test-chart", "1.1.1"}, {"other-chart", "2.1.0"}, {"other-chart", "2.0.1"}},
+			expected:   []bool{false, true, false},
+		},
+		{
+			name:       "lower than existing release",
+			policy:     "highest",
+			makeLatest: true,
+			charts:     [][2]string{{"test-chart", "1.3.0"}},
+			expected:   []bool{false},
+		},
+		{
+			name:       "prerelease",
+			policy:     "highest",
+			makeLatest: true,
+			charts:     [][2]string{{"other-chart", "3.0.0-rc.1"}},
+			expected:   []bool{false},
+		},
+		{
+			name:         "primary chart",
+			policy:       "highest",
+			primaryChart: "test-chart",
+			makeLatest:   true,
+			charts:       [][2]string{{"test-chart", "1.3.0"}, {"other-chart", "3.0.0"}},
+			expected:     []bool{true, false},
+		},
+		{
+			name:         "primary chart backport",
+			policy:       "highest",
+			primaryChart: "test-chart",
+			makeLatest:   true,
+			charts:       [][2]string{{"test-chart", "1.1.1"}},
+			expected:     []bool{false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGitHub := new(FakeGitHub)
+			fakeGitHub.listed = existing
+			fakeGitHub.On("ListReleases", mock.Anything).Return(nil)
+			r := &Releaser{
+				config: &config.Options{
+					ReleaseNameTemplate: "{{ .Name }}-{{ .Version }}",
+					MakeReleaseLatest:   tt.makeLatest,
+					LatestPolicy:        tt.policy,
+					PrimaryChart:        tt.primaryChart,
+				},
+				github: fakeGitHub,
+			}
+
+			var groups []*releaseGroup
+			for _, c := range tt.charts {
+				groups = append(groups, &releaseGroup{
+					name:   c[0] + "-" + c[1],
+					charts: []*chart.Chart{{Metadata: &chart.Metadata{Name: c[0], Version: c[1]}}},
+				})
+			}
+			require.NoError(t, r.markLatestReleases(groups))
+
+			latest := make([]bool, 0, len(groups))
+			for _, group := range groups {
+				latest = append(latest, group.latest)
+			}
+			assert.Equal(t, tt.expected, latest)
+		})
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releaser
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"helm.sh/helm/v3/pkg/chart"
+
+	"github.com/helm/chart-releaser/pkg/git"
+)
+
+// conventionalCommitRegexp matches commit subjects following the Conventional
+// Commits specification, e.g. "feat(ingress)!: add class name"
+var conventionalCommitRegexp = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+
+// commitGroups holds the headings of the commit groups in the order they are rendered
+var commitGroups = []struct {
+	commitType string
+	heading    string
+}{
+	{"!", "Breaking Changes"},
+	{"feat", "Features"},
+	{"fix", "Bug Fixes"},
+	{"perf", "Performance Improvements"},
+	{"docs", "Documentation"},
+	{"", "Other Changes"},
+}
+
+// releaseNotesData is the data the release notes template is rendered with
+type releaseNotesData struct {
+	// Chart is the metadata of the released chart
+	Chart *chart.Metadata
+	// ReleaseName is the computed release name
+	ReleaseName string
+	// Commit is the target commit of the release
+	Commit string
+	// PreviousRelease is the tag of the previous release of the chart, if any
+	PreviousRelease string
+	// Commits are the commits which touched the chart directory since the previous release
+	Commits []git.Commit
+}
+
+// renderReleaseNotesTemplate renders the release notes template for the given chart.
+func (r *Releaser) renderReleaseNotesTemplate(chart *chart.Chart) (string, error) {
+	tmpl, err := template.ParseFiles(r.config.ReleaseNotesTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	releaseName, err := r.computeReleaseName(chart)
+	if err != nil {
+		return "", err
+	}
+	previousTag, commits, err := r.chartHistory(chart.Metadata)
+	if err != nil {
+		return "", err
+	}
+
+	data := &releaseNotesData{
+		Chart:           chart.Metadata,
+		ReleaseName:     releaseName,
+		Commit:          r.config.Commit,
+		PreviousRelease: previousTag,
+		Commits:         commits,
+	}
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, data); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+// chartHistory returns the tag of the previous release of the chart and the
+// commits which touched the chart directory since then. The chart directory is
+// expected in the charts directory, named like the chart.
+func (r *Releaser) chartHistory(metadata *chart.Metadata) (string, []git.Commit, error) {
+	previousTag, err := r.previousReleaseTag(metadata)
+	if err != nil {
+		return "", nil, err
+	}
+
+	revisionRange := r.config.Commit
+	if revisionRange == "" {
+		revisionRange = "HEAD"
+	}
+	if previousTag != "" {
+		revisionRange = previousTag + ".." + revisionRange
+	}
+
+	// Without the chart sources the notes would silently claim there are no changes
+	chartDir := filepath.Join(r.config.ChartsDir, metadata.Name)
+	if stat, err := os.Stat(chartDir); err != nil || !stat.IsDir() {
+		return "", nil, fmt.Errorf("chart source directory %s of chart %s not found, set --charts-dir to the directory holding the chart sources", chartDir, metadata.Name)
+	}
+
+	commits, err := r.git.Log("", revisionRange, chartDir)
+	if err != nil {
+		return "", nil, err
+	}
+	return previousTag, commits, nil
+}
+
+// renderGitReleaseNotes renders the commits which touched the chart directory
+// since the previous release as markdown list.
+func (r *Releaser) renderGitReleaseNotes(chart *chart.Chart) (string, error) {
+	previousTag, commits, err := r.chartHistory(chart.Metadata)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if previousTag != "" {
+		fmt.Fprintf(&b, "## Changes since %s\n", previousTag)
+	} else {
+		b.WriteString("## Changes\n")
+	}
+	if len(commits) == 0 {
+		b.WriteString("\nNo changes\n")
+		return b.String(), nil
+	}
+
+	if !r.config.GroupCommitsByType {
+		b.WriteString("\n")
+		for _, commit := range commits {
+			fmt.Fprintf(&b, "- %s (%s)\n", commit.Subject, shortHash(commit.Hash))
+		}
+		return b.String(), nil
+	}
+
+	grouped := map[string][]string{}
+	for _, commit := range commits {
+		commitType, entry := conventionalCommitEntry(commit)
+		grouped[commitType] = append(grouped[commitType], entry)
+	}
+	for _, group := range commitGroups {
+		entries := grouped[group.commitType]
+		if len(entries) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n### %s\n\n", group.heading)
+		for _, entry := range entries {
+			fmt.Fprintf(&b, "- %s\n", entry)
+		}
+	}
+	return b.String(), nil
+}
+
+// conventionalCommitEntry returns the group of the commit and its list entry.
+// Commits not following the Conventional Commits specification or of an
+// unknown type are grouped as other changes.
+func conventionalCommitEntry(commit git.Commit) (string, string) {
+	match := conventionalCommitRegexp.FindStringSubmatch(commit.Subject)
+	if match == nil {
+		return "", fmt.Sprintf("%s (%s)", commit.Subject, shortHash(commit.Hash))
+	}
+	commitType, scope, breaking, description := strings.ToLower(match[1]), match[2], match[3], match[4]
+
+	entry := description
+	if scope != "" {
+		entry = fmt.Sprintf("**%s:** %s", scope, description)
+	}
+	entry = fmt.Sprintf("%s (%s)", entry, shortHash(commit.Hash))
+
+	if breaking != "" {
+		return "!", entry
+	}
+	for _, group := range commitGroups {
+		if group.commitType == commitType {
+			return commitType, entry
+		}
+	}
+	return "", entry
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
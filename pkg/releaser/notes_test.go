@@ -0,0 +1,183 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releaser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart"
+
+	"github.com/helm/chart-releaser/pkg/config"
+	"github.com/helm/chart-releaser/pkg/git"
+)
+
+func TestReleaser_ReleaseNotesTemplate(t *testing.T) {
+	fakeGit := new(FakeGit)
+	fakeGit.tags = []string{"test-chart-1.0.0", "test-chart-1.1.0"}
+	fakeGit.commits = []git.Commit{
+		{Hash: "5e239bd", Author: "Jane Doe", Subject: "Add ingress class name"},
+		{Hash: "b61c67a", Author: "John Doe", Subject: "Fix service port name"},
+	}
+	fakeGit.On("Tags", mock.Anything).Return(nil)
+	fakeGit.On("Log", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	chartsDir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(chartsDir, "test-chart"), 0755))
+
+	r := &Releaser{
+		config: &config.Options{
+			ReleaseNameTemplate:  "{{ .Name }}-{{ .Version }}",
+			ReleaseNotesTemplate: "testdata/release-notes.tmpl",
+			ChartsDir:            chartsDir,
+			Commit:               "0123456",
+		},
+		git: fakeGit,
+	}
+	ch := &chart.Chart{
+		Metadata: &chart.Metadata{
+			Name:        "test-chart",
+			Version:     "1.2.0",
+			Description: "A Helm chart for Kubernetes",
+			KubeVersion: ">=1.28.0-0",
+			Dependencies: []*chart.Dependency{
+				{Name: "redis", Version: "18.1.0"},
+			},
+		},
+	}
+
+	notes, err := r.getReleaseNotes(ch)
+	require.NoError(t, err)
+	expected := `# test-chart-1.2.0
+
+A Helm chart for Kubernetes
+
+Requires Kubernetes >=1.28.0-0.
+
+## Dependencies
+
+- redis 18.1.0
+
+## Changes since test-chart-1.1.0
+
+- Add ingress class name (5e239bd)
+- Fix service port name (b61c67a)
+`
+	assert.Equal(t, expected, notes)
+	fakeGit.AssertCalled(t, "Log", "", "test-chart-1.1.0..0123456", []string{filepath.Join(chartsDir, "test-chart")})
+}
+
+func TestReleaser_GitReleaseNotes(t *testing.T) {
+	commits := []git.Commit{
+		{Hash: "5e239bd0a1", Subject: "feat(ingress): add class name"},
+		{Hash: "b61c67a0b2", Subject: "fix: service port name"},
+		{Hash: "9f0c1d2e3f", Subject: "feat!: drop support for Kubernetes 1.27"},
+		{Hash: "7a8b9c0d1e", Subject: "Bump appVersion"},
+		{Hash: "1a2b3c4d5e", Subject: "chore: update maintainers"},
+	}
+	tests := []struct {
+		name      string
+		tags      []string
+		commits   []git.Commit
+		group     bool
+		chartsDir string
+		expected  string
+		error     string
+	}{
+		{
+			name:    "ungrouped",
+			tags:    []string{"test-chart-1.0.0", "test-chart-1.1.0"},
+			commits: commits,
+			expected: `## Changes since test-chart-1.1.0
+
+- feat(ingress): add class name (5e239bd)
+- fix: service port name (b61c67a)
+- feat!: drop support for Kubernetes 1.27 (9f0c1d2)
+- Bump appVersion (7a8b9c0)
+- chore: update maintainers (1a2b3c4)
+`,
+		},
+		{
+			name:    "grouped by type",
+			tags:    []string{"test-chart-1.1.0"},
+			commits: commits,
+			group:   true,
+			expected: `## Changes since test-chart-1.1.0
+
+### Breaking Changes
+
+- drop support for Kubernetes 1.27 (9f0c1d2)
+
+### Features
+
+- **ingress:** add class name (5e239bd)
+
+### Bug Fixes
+
+- service port name (b61c67a)
+
+### Other Changes
+
+- Bump appVersion (7a8b9c0)
+- update maintainers (1a2b3c4)
+`,
+		},
+		{
+			name:     "first release without commits",
+			expected: "## Changes\n\nNo changes\n",
+		},
+		{
+			name:      "missing chart sources",
+			chartsDir: "does-not-exist",
+			error:     "chart source directory does-not-exist/test-chart of chart test-chart not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGit := new(FakeGit)
+			fakeGit.tags = tt.tags
+			fakeGit.commits = tt.commits
+			fakeGit.On("Tags", mock.Anything).Return(nil)
+			fakeGit.On("Log", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			chartsDir := tt.chartsDir
+			if chartsDir == "" {
+				chartsDir = t.TempDir()
+				require.NoError(t, os.Mkdir(filepath.Join(chartsDir, "test-chart"), 0755))
+			}
+
+			r := &Releaser{
+				config: &config.Options{
+					ReleaseNameTemplate: "{{ .Name }}-{{ .Version }}",
+					ReleaseNotesFromGit: true,
+					GroupCommitsByType:  tt.group,
+					ChartsDir:           chartsDir,
+				},
+				git: fakeGit,
+			}
+			ch := &chart.Chart{Metadata: &chart.Metadata{Name: "test-chart", Version: "1.2.0"}}
+
+			notes, err := r.getReleaseNotes(ch)
+			if tt.error != "" {
+				assert.ErrorContains(t, err, tt.error)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, notes)
+		})
+	}
+}
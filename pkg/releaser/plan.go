@@ -0,0 +1,196 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releaser
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/repo"
+
+	"github.com/helm/chart-releaser/pkg/github"
+)
+
+// Plan describes the changes a dry run would have made
+type Plan struct {
+	Releases          []*PlannedRelease `json:"releases"`
+	UpdatedReleases   []string          `json:"updatedReleases"`
+	DeletedReleases   []string          `json:"deletedReleases"`
+	AddedEntries      []*PlannedEntry   `json:"addedEntries"`
+	RemovedEntries    []*PlannedEntry   `json:"removedEntries"`
+	DeprecatedEntries []*PlannedEntry   `json:"deprecatedEntries"`
+	Commits           []string          `json:"commits"`
+	Push              string            `json:"push"`
+}
+
+// PlannedRelease is a GitHub release a dry run would have created
+type PlannedRelease struct {
+	Name       string   `json:"name"`
+	Existing   bool     `json:"existing"`
+	Overwrite  bool     `json:"overwrite"`
+	Commit     string   `json:"commit"`
+	MakeLatest string   `json:"makeLatest"`
+	Prerelease bool     `json:"prerelease"`
+	Assets     []string `json:"assets"`
+}
+
+// PlannedEntry is a chart version a dry run would have added to or removed from the index
+type PlannedEntry struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+func newPlan() *Plan {
+	return &Plan{
+		Releases:          []*PlannedRelease{},
+		UpdatedReleases:   []string{},
+		DeletedReleases:   []string{},
+		AddedEntries:      []*PlannedEntry{},
+		RemovedEntries:    []*PlannedEntry{},
+		DeprecatedEntries: []*PlannedEntry{},
+		Commits:           []string{},
+	}
+}
+
+func (p *Plan) addRelease(release *github.Release, existing, overwrite bool) {
+	planned := &PlannedRelease{
+		Name:       release.Name,
+		Existing:   existing,
+		Overwrite:  overwrite,
+		Commit:     release.Commit,
+		MakeLatest: release.MakeLatest,
+		Prerelease: release.Prerelease,
+		Assets:     []string{},
+	}
+	for _, asset := range release.Assets {
+		planned.Assets = append(planned.Assets, filepath.Base(asset.Path))
+	}
+	p.Releases = append(p.Releases, planned)
+}
+
+// addIndexChanges records the chart versions which differ between the given index files.
+func (p *Plan) addIndexChanges(before, after *repo.IndexFile) {
+	p.AddedEntries = append(p.AddedEntries, indexDifference(after, before)...)
+	p.RemovedEntries = append(p.RemovedEntries, indexDifference(before, after)...)
+}
+
+// copyIndexFile returns a copy of the index file entries which is not affected by
+// later changes to the given index file.
+func copyIndexFile(indexFile *repo.IndexFile) *repo.IndexFile {
+	c := repo.NewIndexFile()
+	for name, versions := range indexFile.Entries {
+		c.Entries[name] = append(repo.ChartVersions(nil), versions...)
+	}
+	return c
+}
+
+// indexDifference returns the chart versions of a which are not in b.
+func indexDifference(a, b *repo.IndexFile) []*PlannedEntry {
+	entries := []*PlannedEntry{}
+	for name, versions := range a.Entries {
+		for _, version := range versions {
+			if !b.Has(name, version.Version) {
+				entries = append(entries, &PlannedEntry{Name: name, Version: version.Version})
+			}
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Name != entries[j].Name {
+			return entries[i].Name < entries[j].Name
+		}
+		return entries[i].Version < entries[j].Version
+	})
+	return entries
+}
+
+// String returns the text representation of the plan
+func (p *Plan) String() string {
+	b := strings.Builder{}
+
+	if len(p.Releases) > 0 {
+		b.WriteString("Releases to create:\n")
+		for _, release := range p.Releases {
+			if release.Overwrite {
+				fmt.Fprintf(&b, "  %s (existing release, overwriting assets)\n", release.Name)
+			} else if release.Existing {
+				fmt.Fprintf(&b, "  %s (existing release, missing assets)\n", release.Name)
+			} else if release.Prerelease {
+				fmt.Fprintf(&b, "  %s (commit: %q, latest: %s, prerelease)\n", release.Name, release.Commit, release.MakeLatest)
+			} else {
+				fmt.Fprintf(&b, "  %s (commit: %q, latest: %s)\n", release.Name, release.Commit, release.MakeLatest)
+			}
+			for _, asset := range release.Assets {
+				fmt.Fprintf(&b, "    - %s\n", asset)
+			}
+		}
+	}
+	if len(p.UpdatedReleases) > 0 {
+		b.WriteString("Releases to update:\n")
+		for _, name := range p.UpdatedReleases {
+			fmt.Fprintf(&b, "  %s\n", name)
+		}
+	}
+	if len(p.DeletedReleases) > 0 {
+		b.WriteString("Releases to delete:\n")
+		for _, name := range p.DeletedReleases {
+			fmt.Fprintf(&b, "  %s\n", name)
+		}
+	}
+	if len(p.AddedEntries) > 0 {
+		b.WriteString("Index entries to add:\n")
+		for _, entry := range p.AddedEntries {
+			fmt.Fprintf(&b, "  %s %s\n", entry.Name, entry.Version)
+		}
+	}
+	if len(p.RemovedEntries) > 0 {
+		b.WriteString("Index entries to remove:\n")
+		for _, entry := range p.RemovedEntries {
+			fmt.Fprintf(&b, "  %s %s\n", entry.Name, entry.Version)
+		}
+	}
+	if len(p.DeprecatedEntries) > 0 {
+		b.WriteString("Index entries to deprecate:\n")
+		for _, entry := range p.DeprecatedEntries {
+			fmt.Fprintf(&b, "  %s %s\n", entry.Name, entry.Version)
+		}
+	}
+	if len(p.Commits) > 0 {
+		b.WriteString("Commits:\n")
+		for _, commit := range p.Commits {
+			fmt.Fprintf(&b, "  %s\n", commit)
+		}
+	}
+	if p.Push != "" {
+		fmt.Fprintf(&b, "Push: %s\n", p.Push)
+	}
+	if b.Len() == 0 {
+		b.WriteString("No changes\n")
+	}
+
+	return b.String()
+}
+
+// JSONString returns the JSON representation of the plan
+func (p *Plan) JSONString() (string, error) {
+	b, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
@@ -0,0 +1,68 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releaser
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helm/chart-releaser/pkg/github"
+)
+
+func TestPlan_String(t *testing.T) {
+	plan := newPlan()
+	assert.Equal(t, "No changes\n", plan.String())
+
+	plan.addRelease(&github.Release{
+		Name:       "test-chart-0.1.0",
+		MakeLatest: "true",
+		Assets: []*github.Asset{
+			{Path: "testdata/release-packages/test-chart-0.1.0.tgz"},
+			{Path: "testdata/release-packages/test-chart-0.1.0.tgz.prov"},
+		},
+	}, false, false)
+	plan.AddedEntries = append(plan.AddedEntries, &PlannedEntry{Name: "test-chart", Version: "0.1.0"})
+	plan.Commits = append(plan.Commits, "Update index.yaml")
+	plan.Push = `push to branch "gh-pages"`
+
+	expected := `Releases to create:
+  test-chart-0.1.0 (commit: "", latest: true)
+    - test-chart-0.1.0.tgz
+    - test-chart-0.1.0.tgz.prov
+Index entries to add:
+  test-chart 0.1.0
+Commits:
+  Update index.yaml
+Push: push to branch "gh-pages"
+`
+	assert.Equal(t, expected, plan.String())
+}
+
+func TestPlan_JSONString(t *testing.T) {
+	plan := newPlan()
+	plan.RemovedEntries = append(plan.RemovedEntries, &PlannedEntry{Name: "test-chart", Version: "0.1.0"})
+
+	j, err := plan.JSONString()
+	require.NoError(t, err)
+
+	var actual map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(j), &actual))
+	assert.Equal(t, []interface{}{}, actual["releases"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "test-chart", "version": "0.1.0"}}, actual["removedEntries"])
+	assert.Equal(t, "", actual["push"])
+}
@@ -15,7 +15,6 @@
 package releaser
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -28,20 +27,22 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/Songmu/retry"
 
-	"text/template"
-
 	"helm.sh/helm/v3/pkg/chart"
 
 	"helm.sh/helm/v3/pkg/chart/loader"
 
+	"github.com/helm/chart-releaser/internal/concurrent"
 	"github.com/helm/chart-releaser/pkg/config"
 
 	"helm.sh/helm/v3/pkg/provenance"
 	"helm.sh/helm/v3/pkg/repo"
 
+	"github.com/helm/chart-releaser/pkg/git"
 	"github.com/helm/chart-releaser/pkg/github"
+	"github.com/helm/chart-releaser/pkg/releasename"
 )
 
 // GitHub contains the functions necessary for interacting with GitHub release
@@ -49,6 +50,12 @@ import (
 type GitHub interface {
 	CreateRelease(ctx context.Context, input *github.Release) error
 	GetRelease(ctx context.Context, tag string) (*github.Release, error)
+	ListReleases(ctx context.Context) ([]*github.Release, error)
+	DownloadReleaseAsset(ctx context.Context, asset *github.Asset, filename string) error
+	DeleteRelease(ctx context.Context, tag string) error
+	UploadReleaseAsset(ctx context.Context, releaseID int64, filename string) error
+	DeleteReleaseAsset(ctx context.Context, assetID int64) error
+	UpdateRelease(ctx context.Context, input *github.Release) error
 	CreatePullRequest(owner string, repo string, message string, head string, base string) (string, error)
 }
 
@@ -60,12 +67,17 @@ type Git interface {
 	Push(workingDir string, args ...string) error
 	Pull(workingDir string, args ...string) error
 	GetPushURL(remote string, token string) (string, error)
+	Tags(workingDir string) ([]string, error)
+	Log(workingDir string, revisionRange string, paths ...string) ([]git.Commit, error)
 }
 
 var letters = []rune("abcdefghijklmnopqrstuvwxyz0123456789")
 
 const chartAssetFileExtension = ".tgz"
 
+// errNotChartPackage is returned for archives which cannot be loaded as chart package
+var errNotChartPackage = errors.New("not a helm chart package")
+
 func init() {
 	rand.New(rand.NewSource(time.Now().UnixNano())) // nolint: gosec
 }
@@ -74,6 +86,9 @@ type Releaser struct {
 	config *config.Options
 	github GitHub
 	git    Git
+	plan   *Plan
+	// created holds the names of the releases created in this run
+	created []string
 }
 
 func NewReleaser(config *config.Options, github GitHub, git Git) *Releaser {
@@ -84,8 +99,85 @@ func NewReleaser(config *config.Options, github GitHub, git Git) *Releaser {
 	}
 }
 
+// Plan returns the changes recorded instead of being made when running with dry-run enabled
+func (r *Releaser) Plan() *Plan {
+	if r.plan == nil {
+		r.plan = newPlan()
+	}
+	return r.plan
+}
+
 // UpdateIndexFile updates the index.yaml file for a given Git repo
 func (r *Releaser) UpdateIndexFile() (bool, error) {
+	worktree, indexYamlPath, indexFile, err := r.loadIndexFile()
+	if err != nil {
+		return false, err
+	}
+	defer r.git.RemoveWorktree("", worktree) // nolint: errcheck
+
+	original := copyIndexFile(indexFile)
+
+	var update bool
+	if r.config.Rebuild {
+		indexFile, err = r.rebuildIndexFile()
+		if err != nil {
+			return false, err
+		}
+		update = true
+	} else {
+		update, err = r.addPackagesToIndexFile(indexFile)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if r.config.Prune {
+		pruned, err := r.pruneIndexFile(indexFile)
+		if err != nil {
+			return false, err
+		}
+		update = update || pruned
+	}
+
+	removed := r.applyRetentionPolicy(indexFile)
+	update = update || len(removed) > 0
+
+	if !update {
+		fmt.Printf("Index %s did not change\n", r.config.IndexPath)
+		return false, nil
+	}
+
+	fmt.Printf("Updating index %s\n", r.config.IndexPath)
+	indexFile.SortEntries()
+
+	indexFile.Generated = time.Now()
+
+	if r.config.DryRun {
+		r.Plan().addIndexChanges(original, indexFile)
+	} else if err := indexFile.WriteFile(r.config.IndexPath, 0644); err != nil {
+		return false, err
+	}
+
+	if r.config.Push || r.config.PR {
+		if err := r.commitIndexFile(worktree, indexYamlPath, fmt.Sprintf("Update %s", r.config.PagesIndexPath)); err != nil {
+			return false, err
+		}
+	}
+
+	// Releases are only deleted once the index without them has been pushed
+	if r.config.RetentionDeleteReleases && r.config.Push {
+		if err := r.deleteReleases(removed); err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// loadIndexFile adds a worktree for the GitHub Pages branch and loads the index
+// file from it. A new index file is returned if the branch has none. The caller
+// must remove the returned worktree.
+func (r *Releaser) loadIndexFile() (string, string, *repo.IndexFile, error) {
 	// if index-path doesn't end with index.yaml we can try and fix it
 	if filepath.Base(r.config.IndexPath) != "index.yaml" {
 		// if path is a directory then add index.yaml
@@ -101,9 +193,8 @@ func (r *Releaser) UpdateIndexFile() (bool, error) {
 	fmt.Printf("Loading index file from git repository %s\n", r.config.IndexPath)
 	worktree, err := r.git.AddWorktree("", r.config.Remote+"/"+r.config.PagesBranch)
 	if err != nil {
-		return false, err
+		return "", "", nil, err
 	}
-	defer r.git.RemoveWorktree("", worktree) // nolint: errcheck
 
 	// if pages-index-path doesn't end with index.yaml we can try and fix it
 	if filepath.Base(r.config.PagesIndexPath) != "index.yaml" {
@@ -123,14 +214,51 @@ func (r *Releaser) UpdateIndexFile() (bool, error) {
 	if err == nil { // nolint: gocritic
 		indexFile, err = repo.LoadIndexFile(indexYamlPath)
 		if err != nil {
-			return false, err
+			r.git.RemoveWorktree("", worktree) // nolint: errcheck
+			return "", "", nil, err
 		}
 	} else if errors.Is(err, os.ErrNotExist) {
 		indexFile = repo.NewIndexFile()
 	} else {
-		return false, err
+		r.git.RemoveWorktree("", worktree) // nolint: errcheck
+		return "", "", nil, err
+	}
+	return worktree, indexYamlPath, indexFile, nil
+}
+
+// commitIndexFile copies the index file to the pages branch worktree, commits it
+// along with the given paths of the worktree and pushes it or creates a pull
+// request.
+func (r *Releaser) commitIndexFile(worktree string, indexYamlPath string, message string, paths ...string) error {
+	if r.config.DryRun {
+		r.Plan().Commits = append(r.Plan().Commits, message)
+		r.planPushToPagesBranch()
+		return nil
 	}
 
+	if err := copyFile(r.config.IndexPath, indexYamlPath); err != nil {
+		return err
+	}
+
+	if err := r.git.Pull(worktree, r.config.Remote, r.config.PagesBranch); err != nil {
+		return err
+	}
+
+	if err := r.git.Add(worktree, append([]string{indexYamlPath}, paths...)...); err != nil {
+		return err
+	}
+
+	if err := r.git.Commit(worktree, message); err != nil {
+		return err
+	}
+
+	return r.pushToPagesBranch(worktree)
+}
+
+// addPackagesToIndexFile adds the chart packages found in the package path to the
+// index file, using the assets of their GitHub releases. It reports whether the
+// index file was changed.
+func (r *Releaser) addPackagesToIndexFile(indexFile *repo.IndexFile) (bool, error) {
 	// We have to explicitly glob for *.tgz files only. If GPG signing is enabled,
 	// this would also return *.tgz.prov files otherwise, which we don't want here.
 	chartPackages, err := filepath.Glob(r.config.PackagePath + "/*.tgz")
@@ -138,7 +266,14 @@ func (r *Releaser) UpdateIndexFile() (bool, error) {
 		return false, err
 	}
 
-	var update bool
+	dir, err := os.MkdirTemp("", "chart-releaser-")
+	if err != nil {
+		return false, err
+	}
+	defer os.RemoveAll(dir)
+
+	var releaseNames []string
+	seen := map[string]bool{}
 	for _, chartPackage := range chartPackages {
 		ch, err := loader.LoadFile(chartPackage)
 		if err != nil {
@@ -148,19 +283,30 @@ func (r *Releaser) UpdateIndexFile() (bool, error) {
 		if err != nil {
 			return false, err
 		}
+		// a release may hold several chart packages
+		if seen[releaseName] {
+			continue
+		}
+		seen[releaseName] = true
+		releaseNames = append(releaseNames, releaseName)
+	}
 
-		var release *github.Release
-		if err := retry.Retry(3, 3*time.Second, func() error {
-			rel, err := r.github.GetRelease(context.TODO(), releaseName)
+	releases := make([]*github.Release, len(releaseNames))
+	if err := concurrent.Run(len(releaseNames), r.config.Concurrency, func(i int) error {
+		return retry.Retry(3, 3*time.Second, func() error {
+			rel, err := r.github.GetRelease(context.TODO(), releaseNames[i])
 			if err != nil {
 				return err
 			}
-			release = rel
+			releases[i] = rel
 			return nil
-		}); err != nil {
-			return false, err
-		}
+		})
+	}); err != nil {
+		return false, err
+	}
 
+	var update bool
+	for _, release := range releases {
 		for _, asset := range release.Assets {
 			downloadURL, _ := url.Parse(asset.URL)
 			name := filepath.Base(downloadURL.Path)
@@ -169,85 +315,221 @@ func (r *Releaser) UpdateIndexFile() (bool, error) {
 				continue
 			}
 			baseName := strings.TrimSuffix(name, filepath.Ext(name))
+			if strings.LastIndex(baseName, "-") < 0 {
+				fmt.Printf("Skipping %s: not a chart package\n", name)
+				continue
+			}
 			tagParts := r.splitPackageNameAndVersion(baseName)
 			packageName, packageVersion := tagParts[0], tagParts[1]
 			fmt.Printf("Found %s-%s.tgz\n", packageName, packageVersion)
-			if _, err := indexFile.Get(packageName, packageVersion); err != nil {
-				if err := r.addToIndexFile(indexFile, downloadURL.String()); err != nil {
+			var replaced *repo.ChartVersion
+			if _, err := indexFile.Get(packageName, packageVersion); err == nil {
+				if !r.config.Overwrite {
+					continue
+				}
+				// The release may have been overwritten, so its entry gets the current digest
+				fmt.Printf("Replacing %s-%s in index\n", packageName, packageVersion)
+				replaced = removeIndexEntry(indexFile, packageName, packageVersion)
+			}
+
+			arch := filepath.Join(r.config.PackagePath, name)
+			var downloaded bool
+			if _, err := os.Stat(arch); errors.Is(err, os.ErrNotExist) {
+				// The release holds a chart package which is not available locally.
+				arch = filepath.Join(dir, name)
+				if err := r.github.DownloadReleaseAsset(context.TODO(), asset, arch); err != nil {
+					return false, err
+				}
+				downloaded = true
+			}
+			if err := r.addArchiveToIndexFile(indexFile, arch, downloadURL.String()); err != nil {
+				// Other .tgz files attached to the release by the users are skipped
+				if !downloaded || !errors.Is(err, errNotChartPackage) {
 					return false, err
 				}
-				update = true
-				break
+				fmt.Printf("Skipping %s: %s\n", name, err)
+				if replaced != nil {
+					indexFile.Entries[packageName] = append(indexFile.Entries[packageName], replaced)
+				}
+				continue
 			}
+			update = true
 		}
 	}
 
-	if !update {
-		fmt.Printf("Index %s did not change\n", r.config.IndexPath)
-		return false, nil
-	}
-
-	fmt.Printf("Updating index %s\n", r.config.IndexPath)
-	indexFile.SortEntries()
-
-	indexFile.Generated = time.Now()
+	return update, nil
+}
 
-	if err := indexFile.WriteFile(r.config.IndexPath, 0644); err != nil {
-		return false, err
+// rebuildIndexFile creates a new index file from the chart packages attached to
+// all GitHub releases of the repository.
+func (r *Releaser) rebuildIndexFile() (*repo.IndexFile, error) {
+	fmt.Println("Rebuilding index from all GitHub releases")
+	releases, err := r.github.ListReleases(context.TODO())
+	if err != nil {
+		return nil, err
 	}
 
-	if !r.config.Push && !r.config.PR {
-		return true, nil
+	dir, err := os.MkdirTemp("", "chart-releaser-")
+	if err != nil {
+		return nil, err
 	}
+	defer os.RemoveAll(dir)
+
+	indexFile := repo.NewIndexFile()
+	for _, release := range releases {
+		if release.Draft {
+			// Assets of draft releases cannot be downloaded publicly
+			fmt.Printf("Skipping draft release %s\n", release.Name)
+			continue
+		}
+		for _, asset := range release.Assets {
+			downloadURL, _ := url.Parse(asset.URL)
+			name := filepath.Base(downloadURL.Path)
+			if filepath.Ext(name) != chartAssetFileExtension {
+				continue
+			}
+			baseName := strings.TrimSuffix(name, filepath.Ext(name))
+			if strings.LastIndex(baseName, "-") < 0 {
+				continue
+			}
+			tagParts := r.splitPackageNameAndVersion(baseName)
+			if indexFile.Has(tagParts[0], tagParts[1]) {
+				continue
+			}
 
-	if err := copyFile(r.config.IndexPath, indexYamlPath); err != nil {
-		return false, err
+			fmt.Printf("Downloading %s from release %s\n", name, release.Name)
+			arch := filepath.Join(dir, name)
+			if err := r.github.DownloadReleaseAsset(context.TODO(), asset, arch); err != nil {
+				return nil, err
+			}
+			if err := r.addArchiveToIndexFile(indexFile, arch, downloadURL.String()); err != nil {
+				if !errors.Is(err, errNotChartPackage) {
+					return nil, err
+				}
+				fmt.Printf("Skipping %s: %s\n", name, err)
+				continue
+			}
+		}
 	}
+	return indexFile, nil
+}
 
-	if err := r.git.Pull(worktree, r.config.Remote, r.config.PagesBranch); err != nil {
+// pruneIndexFile removes all chart versions from the index file whose GitHub
+// release no longer exists or is a draft. It reports whether the index file was changed.
+func (r *Releaser) pruneIndexFile(indexFile *repo.IndexFile) (bool, error) {
+	releases, err := r.github.ListReleases(context.TODO())
+	if err != nil {
 		return false, err
 	}
-
-	if err := r.git.Add(worktree, indexYamlPath); err != nil {
-		return false, err
+	releaseNames := make(map[string]bool, len(releases))
+	for _, release := range releases {
+		if !release.Draft {
+			releaseNames[release.Name] = true
+		}
 	}
 
-	if err := r.git.Commit(worktree, fmt.Sprintf("Update %s", r.config.PagesIndexPath)); err != nil {
-		return false, err
+	var pruned bool
+	for name, versions := range indexFile.Entries {
+		var kept repo.ChartVersions
+		for _, version := range versions {
+			releaseName, err := r.computeReleaseName(&chart.Chart{Metadata: version.Metadata})
+			if err != nil {
+				return false, err
+			}
+			if !releaseNames[releaseName] {
+				fmt.Printf("Removing %s-%s from index, release %s does not exist\n", name, version.Version, releaseName)
+				pruned = true
+				continue
+			}
+			kept = append(kept, version)
+		}
+		if len(kept) == 0 {
+			delete(indexFile.Entries, name)
+		} else {
+			indexFile.Entries[name] = kept
+		}
 	}
+	return pruned, nil
+}
 
-	if err := r.pushToPagesBranch(worktree); err != nil {
-		return false, err
+func (r *Releaser) computeReleaseName(chart *chart.Chart) (string, error) {
+	return releasename.Render(r.config.ReleaseNameTemplate, chart.Metadata)
+}
+
+// getReleaseNotes returns the release notes of the chart, followed by the changes
+// of its artifacthub.io/changes annotation if enabled.
+func (r *Releaser) getReleaseNotes(chart *chart.Chart) (string, error) {
+	notes, err := r.getChartReleaseNotes(chart)
+	if err != nil || !r.config.ArtifactHubChanges {
+		return notes, err
 	}
 
-	return true, nil
+	changes, err := renderArtifactHubChanges(chart.Metadata)
+	if err != nil || changes == "" {
+		return notes, err
+	}
+	if notes = strings.TrimRight(notes, "\n"); notes != "" {
+		notes += "\n\n"
+	}
+	return notes + changes, nil
 }
 
-func (r *Releaser) computeReleaseName(chart *chart.Chart) (string, error) {
-	tmpl, err := template.New("gotpl").Parse(r.config.ReleaseNameTemplate)
-	if err != nil {
-		return "", err
+// getChartReleaseNotes returns the release notes of the chart from the first
+// configured source.
+func (r *Releaser) getChartReleaseNotes(chart *chart.Chart) (string, error) {
+	if r.config.ReleaseNotesTemplate != "" {
+		return r.renderReleaseNotesTemplate(chart)
 	}
-
-	var buffer bytes.Buffer
-	if err := tmpl.Execute(&buffer, chart.Metadata); err != nil {
-		return "", err
+	if r.config.ReleaseNotesFromGit {
+		return r.renderGitReleaseNotes(chart)
 	}
 
-	releaseName := buffer.String()
-	return releaseName, nil
-}
+	if r.config.ChangelogFile != "" {
+		section, ok, err := r.getChangelogSection(chart)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			return section, nil
+		}
+		switch r.config.ChangelogFallback {
+		case "", changelogFallbackNotes:
+		case changelogFallbackEmpty:
+			return "", nil
+		case changelogFallbackError:
+			return "", fmt.Errorf("no changelog section found for %s-%s", chart.Metadata.Name, chart.Metadata.Version)
+		default:
+			return "", fmt.Errorf("unknown changelog fallback %q", r.config.ChangelogFallback)
+		}
+	}
 
-func (r *Releaser) getReleaseNotes(chart *chart.Chart) string {
 	if r.config.ReleaseNotesFile != "" {
 		for _, f := range chart.Files {
 			if f.Name == r.config.ReleaseNotesFile {
-				return string(f.Data)
+				return string(f.Data), nil
 			}
 		}
 		fmt.Printf("The release note file %q, is not present in the chart package\n", r.config.ReleaseNotesFile)
 	}
-	return chart.Metadata.Description
+	return chart.Metadata.Description, nil
+}
+
+// getGroupReleaseNotes returns the release notes for a release group. Releases
+// holding several charts get a section per chart.
+func (r *Releaser) getGroupReleaseNotes(group *releaseGroup) (string, error) {
+	if len(group.charts) == 1 {
+		return r.getReleaseNotes(group.charts[0])
+	}
+
+	sections := make([]string, 0, len(group.charts))
+	for _, ch := range group.charts {
+		notes, err := r.getReleaseNotes(ch)
+		if err != nil {
+			return "", err
+		}
+		sections = append(sections, fmt.Sprintf("## %s %s\n\n%s", ch.Metadata.Name, ch.Metadata.Version, notes))
+	}
+	return strings.Join(sections, "\n\n"), nil
 }
 
 func (r *Releaser) splitPackageNameAndVersion(pkg string) []string {
@@ -257,12 +539,15 @@ func (r *Releaser) splitPackageNameAndVersion(pkg string) []string {
 
 func (r *Releaser) addToIndexFile(indexFile *repo.IndexFile, url string) error {
 	arch := filepath.Join(r.config.PackagePath, filepath.Base(url))
+	return r.addArchiveToIndexFile(indexFile, arch, url)
+}
 
+func (r *Releaser) addArchiveToIndexFile(indexFile *repo.IndexFile, arch string, url string) error {
 	// extract chart metadata
 	fmt.Printf("Extracting chart metadata from %s\n", arch)
 	c, err := loader.LoadFile(arch)
 	if err != nil {
-		return fmt.Errorf("%s is not a helm chart package: %w", arch, err)
+		return fmt.Errorf("%s is %w: %w", arch, errNotChartPackage, err)
 	}
 	// calculate hash
 	fmt.Printf("Calculating Hash for %s\n", arch)
@@ -287,8 +572,19 @@ func (r *Releaser) addToIndexFile(indexFile *repo.IndexFile, url string) error {
 	return indexFile.MustAdd(c.Metadata, filepath.Base(arch), strings.Join(s, "/"), hash)
 }
 
-// CreateReleases finds and uploads Helm chart packages to GitHub
+// CreateReleases finds and uploads Helm chart packages to GitHub. In atomic
+// mode, all releases created in this run are deleted again if anything fails.
 func (r *Releaser) CreateReleases() error {
+	err := r.createReleases()
+	if err != nil && r.config.Atomic {
+		if rollbackErr := r.rollbackReleases(); rollbackErr != nil {
+			return errors.Join(err, rollbackErr)
+		}
+	}
+	return err
+}
+
+func (r *Releaser) createReleases() error {
 	worktree := ""
 	if r.config.PackagesWithIndex {
 		var err error
@@ -309,52 +605,66 @@ func (r *Releaser) CreateReleases() error {
 		return fmt.Errorf("no charts found at %s", r.config.PackagePath)
 	}
 
-	for _, p := range packages {
-		ch, err := loader.LoadFile(p)
-		if err != nil {
-			return err
+	groups, err := r.groupPackagesByRelease(packages)
+	if err != nil {
+		return err
+	}
+	if err := r.markLatestReleases(groups); err != nil {
+		return err
+	}
+
+	// Releases are created concurrently. Everything touching the pages branch
+	// worktree happens afterwards in package order.
+	results := make([]*releaseResult, len(groups))
+	err = concurrent.Run(len(groups), r.config.Concurrency, func(i int) error {
+		results[i] = &releaseResult{}
+		return r.publishRelease(groups[i], results[i])
+	})
+	for i, result := range results {
+		for _, line := range result.logs {
+			fmt.Println(line)
 		}
-		releaseName, err := r.computeReleaseName(ch)
-		if err != nil {
-			return err
+		if result.release != nil && !result.resumed && !result.overwritten && !r.config.DryRun {
+			r.created = append(r.created, groups[i].name)
 		}
+	}
+	if err != nil {
+		return err
+	}
 
-		release := &github.Release{
-			Name:        releaseName,
-			Description: r.getReleaseNotes(ch),
-			Assets: []*github.Asset{
-				{Path: p},
-			},
-			Commit:               r.config.Commit,
-			GenerateReleaseNotes: r.config.GenerateReleaseNotes,
-			MakeLatest:           strconv.FormatBool(r.config.MakeReleaseLatest),
-		}
-		provFile := fmt.Sprintf("%s.prov", p)
-		if _, err := os.Stat(provFile); err == nil {
-			asset := &github.Asset{Path: provFile}
-			release.Assets = append(release.Assets, asset)
-		}
-		if r.config.SkipExisting {
-			existingRelease, _ := r.github.GetRelease(context.TODO(), releaseName)
-			if existingRelease != nil {
+	if r.config.DryRun {
+		for i, result := range results {
+			if result.release == nil {
 				continue
 			}
+			r.Plan().addRelease(result.release, result.resumed || result.overwritten, result.overwritten)
+			if r.config.PackagesWithIndex {
+				r.Plan().Commits = append(r.Plan().Commits, fmt.Sprintf("Publishing chart package for %s", groups[i].name))
+			}
 		}
-		if err := r.github.CreateRelease(context.TODO(), release); err != nil {
-			return fmt.Errorf("error creating GitHub release %s: %w", releaseName, err)
+		if r.config.Push {
+			r.planPushToPagesBranch()
 		}
+		return nil
+	}
 
-		if r.config.PackagesWithIndex {
-			pkgTargetPath := filepath.Join(worktree, filepath.Base(p))
-			if err := copyFile(p, pkgTargetPath); err != nil {
-				return err
+	if r.config.PackagesWithIndex {
+		for i, group := range groups {
+			if results[i].release == nil {
+				continue
 			}
+			for _, p := range group.packages {
+				pkgTargetPath := filepath.Join(worktree, filepath.Base(p))
+				if err := copyFile(p, pkgTargetPath); err != nil {
+					return err
+				}
 
-			if err := r.git.Add(worktree, pkgTargetPath); err != nil {
-				return err
+				if err := r.git.Add(worktree, pkgTargetPath); err != nil {
+					return err
+				}
 			}
 
-			if err := r.git.Commit(worktree, fmt.Sprintf("Publishing chart package for %s", releaseName)); err != nil {
+			if err := r.git.Commit(worktree, fmt.Sprintf("Publishing chart package for %s", group.name)); err != nil {
 				return err
 			}
 		}
@@ -368,10 +678,278 @@ func (r *Releaser) CreateReleases() error {
 	return nil
 }
 
+// rollbackReleases deletes all releases and their tags created in this run.
+func (r *Releaser) rollbackReleases() error {
+	var errs []error
+	for _, name := range r.created {
+		fmt.Printf("Rolling back release %s\n", name)
+		if err := r.github.DeleteRelease(context.TODO(), name); err != nil {
+			errs = append(errs, fmt.Errorf("error rolling back GitHub release %s: %w", name, err))
+		}
+	}
+	r.created = nil
+	return errors.Join(errs...)
+}
+
+// releaseResult records what publishRelease did for a release group
+type releaseResult struct {
+	// release holds the release or, for resumed releases, the assets that were
+	// published. It is nil if nothing was published.
+	release     *github.Release
+	resumed     bool
+	overwritten bool
+	// logs holds progress messages, which are printed once all releases are done
+	logs []string
+}
+
+func (result *releaseResult) logf(format string, a ...any) {
+	result.logs = append(result.logs, fmt.Sprintf(format, a...))
+}
+
+// publishRelease creates the GitHub release for a release group. In dry-run
+// mode the release is only recorded in the result.
+func (r *Releaser) publishRelease(group *releaseGroup, result *releaseResult) error {
+	notes, err := r.getGroupReleaseNotes(group)
+	if err != nil {
+		return err
+	}
+	release := &github.Release{
+		Name:                 group.name,
+		Description:          notes,
+		Commit:               r.config.Commit,
+		GenerateReleaseNotes: r.config.GenerateReleaseNotes,
+		MakeLatest:           strconv.FormatBool(group.latest),
+		Draft:                r.config.DraftRelease || r.config.Atomic,
+		Prerelease:           group.prerelease(),
+	}
+	if release.Assets, err = r.releaseAssets(group); err != nil {
+		return err
+	}
+	if r.config.ChecksumsFile != "" {
+		dir, err := os.MkdirTemp("", "chart-releaser-")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(dir)
+
+		checksums, err := r.writeChecksumsFile(group, release.Assets, dir)
+		if err != nil {
+			return err
+		}
+		release.Assets = append(release.Assets, checksums)
+	}
+	if r.config.Overwrite {
+		existingRelease, _ := r.github.GetRelease(context.TODO(), group.name)
+		if existingRelease != nil {
+			return r.overwriteRelease(existingRelease, release, result)
+		}
+	}
+	if r.config.Resume {
+		existingRelease, _ := r.github.GetRelease(context.TODO(), group.name)
+		if existingRelease != nil {
+			return r.resumeRelease(existingRelease, release, result)
+		}
+	}
+	if r.config.SkipExisting {
+		existingRelease, _ := r.github.GetRelease(context.TODO(), group.name)
+		if existingRelease != nil {
+			return nil
+		}
+	}
+	if !r.config.DryRun {
+		if err := r.github.CreateRelease(context.TODO(), release); err != nil {
+			return fmt.Errorf("error creating GitHub release %s: %w", group.name, err)
+		}
+	}
+	result.release = release
+	return nil
+}
+
+// resumeRelease uploads the assets of release which are missing in the existing
+// release. Assets present in both must match the local files.
+func (r *Releaser) resumeRelease(existing *github.Release, release *github.Release, result *releaseResult) error {
+	existingAssets := make(map[string]*github.Asset, len(existing.Assets))
+	for _, asset := range existing.Assets {
+		existingAssets[filepath.Base(asset.Path)] = asset
+	}
+
+	var missing []*github.Asset
+	for _, asset := range release.Assets {
+		existingAsset, ok := existingAssets[filepath.Base(asset.Path)]
+		if !ok {
+			missing = append(missing, asset)
+			continue
+		}
+		if err := r.verifyReleaseAsset(existingAsset, asset.Path); err != nil {
+			return fmt.Errorf("error resuming GitHub release %s: %w", release.Name, err)
+		}
+	}
+
+	if len(missing) == 0 {
+		result.logf("Release %s already exists with all assets", release.Name)
+		return nil
+	}
+	for _, asset := range missing {
+		result.logf("Uploading missing asset %s to existing release %s", filepath.Base(asset.Path), release.Name)
+		if r.config.DryRun {
+			continue
+		}
+		if err := r.github.UploadReleaseAsset(context.TODO(), existing.ID, asset.Path); err != nil {
+			return fmt.Errorf("error resuming GitHub release %s: %w", release.Name, err)
+		}
+	}
+	release.Assets = missing
+	result.release = release
+	result.resumed = true
+	return nil
+}
+
+// overwriteRelease replaces the chart packages, provenance files and other assets
+// of the existing release by the assets of release and updates its body. Releases
+// older than the configured maximum age are never overwritten.
+func (r *Releaser) overwriteRelease(existing *github.Release, release *github.Release, result *releaseResult) error {
+	if r.config.OverwriteMaxAge > 0 && time.Since(existing.CreatedAt) > r.config.OverwriteMaxAge {
+		return fmt.Errorf("refusing to overwrite GitHub release %s created at %s, which is older than %s",
+			release.Name, existing.CreatedAt.Format(time.RFC3339), r.config.OverwriteMaxAge)
+	}
+
+	replaced := make(map[string]bool, len(release.Assets))
+	for _, asset := range release.Assets {
+		replaced[filepath.Base(asset.Path)] = true
+	}
+
+	result.logf("Overwriting existing release %s created at %s", release.Name, existing.CreatedAt.Format(time.RFC3339))
+	for _, asset := range existing.Assets {
+		name := filepath.Base(asset.Path)
+		if !replaced[name] && filepath.Ext(name) != chartAssetFileExtension && !strings.HasSuffix(name, chartAssetFileExtension+".prov") {
+			continue
+		}
+		result.logf("Deleting asset %s of release %s", name, release.Name)
+		if r.config.DryRun {
+			continue
+		}
+		if err := r.github.DeleteReleaseAsset(context.TODO(), asset.ID); err != nil {
+			return fmt.Errorf("error overwriting GitHub release %s: %w", release.Name, err)
+		}
+	}
+	for _, asset := range release.Assets {
+		result.logf("Uploading asset %s to release %s", filepath.Base(asset.Path), release.Name)
+		if r.config.DryRun {
+			continue
+		}
+		if err := r.github.UploadReleaseAsset(context.TODO(), existing.ID, asset.Path); err != nil {
+			return fmt.Errorf("error overwriting GitHub release %s: %w", release.Name, err)
+		}
+	}
+
+	result.logf("Updating body of release %s", release.Name)
+	if !r.config.DryRun {
+		update := &github.Release{ID: existing.ID, Name: existing.Name, Description: release.Description}
+		if err := r.github.UpdateRelease(context.TODO(), update); err != nil {
+			return fmt.Errorf("error overwriting GitHub release %s: %w", release.Name, err)
+		}
+	}
+	result.release = release
+	result.overwritten = true
+	return nil
+}
+
+// verifyReleaseAsset checks that the given release asset has the same size and
+// digest as the local file.
+func (r *Releaser) verifyReleaseAsset(asset *github.Asset, path string) error {
+	name := filepath.Base(path)
+	stat, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if stat.Size() != asset.Size {
+		return fmt.Errorf("existing asset %s has size %d, but local file has size %d", name, asset.Size, stat.Size())
+	}
+
+	dir, err := os.MkdirTemp("", "chart-releaser-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	downloaded := filepath.Join(dir, name)
+	if err := r.github.DownloadReleaseAsset(context.TODO(), asset, downloaded); err != nil {
+		return err
+	}
+	existingDigest, err := provenance.DigestFile(downloaded)
+	if err != nil {
+		return err
+	}
+	localDigest, err := provenance.DigestFile(path)
+	if err != nil {
+		return err
+	}
+	if existingDigest != localDigest {
+		return fmt.Errorf("existing asset %s has digest %s, but local file has digest %s", name, existingDigest, localDigest)
+	}
+	return nil
+}
+
+// releaseGroup holds the chart packages which are published in the same release
+type releaseGroup struct {
+	name     string
+	packages []string
+	charts   []*chart.Chart
+	latest   bool
+}
+
+// prerelease returns whether any chart of the group has a semver prerelease
+// version, e.g. 1.2.0-rc.1. Such releases are never marked as latest.
+func (g *releaseGroup) prerelease() bool {
+	for _, ch := range g.charts {
+		if v, err := semver.NewVersion(ch.Metadata.Version); err == nil && v.Prerelease() != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// groupPackagesByRelease groups the given chart packages by their computed release
+// name, so that all packages rendering to the same name end up in one release.
+// Groups are returned in the order their first package was given.
+func (r *Releaser) groupPackagesByRelease(packages []string) ([]*releaseGroup, error) {
+	var groups []*releaseGroup
+	byName := map[string]*releaseGroup{}
+	for _, p := range packages {
+		ch, err := loader.LoadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		releaseName, err := r.computeReleaseName(ch)
+		if err != nil {
+			return nil, err
+		}
+
+		group, ok := byName[releaseName]
+		if !ok {
+			group = &releaseGroup{name: releaseName}
+			byName[releaseName] = group
+			groups = append(groups, group)
+		}
+		group.packages = append(group.packages, p)
+		group.charts = append(group.charts, ch)
+	}
+	return groups, nil
+}
+
 func (r *Releaser) getListOfPackages(dir string) ([]string, error) {
 	return filepath.Glob(filepath.Join(dir, "*.tgz"))
 }
 
+// planPushToPagesBranch records the push pushToPagesBranch would make in the plan.
+func (r *Releaser) planPushToPagesBranch() {
+	if r.config.Push {
+		r.Plan().Push = fmt.Sprintf("push to branch %q", r.config.PagesBranch)
+	} else if r.config.PR {
+		r.Plan().Push = fmt.Sprintf("pull request against branch %q", r.config.PagesBranch)
+	}
+}
+
 func (r *Releaser) pushToPagesBranch(worktree string) error {
 	pushURL, err := r.git.GetPushURL(r.config.Remote, r.config.Token)
 	if err != nil {
@@ -16,11 +16,16 @@ package releaser
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/helm/chart-releaser/pkg/git"
 	"github.com/helm/chart-releaser/pkg/github"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -33,11 +38,19 @@ import (
 
 type FakeGitHub struct {
 	mock.Mock
-	release *github.Release
+	mutex       sync.Mutex
+	release     *github.Release
+	releases    []*github.Release
+	listed      []*github.Release
+	extraAssets []*github.Asset
+	createdAt   time.Time
 }
 
 type FakeGit struct {
-	indexFile string
+	indexFile  string
+	pagesFiles []string
+	tags       []string
+	commits    []git.Commit
 	mock.Mock
 }
 
@@ -46,6 +59,11 @@ func (f *FakeGit) AddWorktree(workingDir string, committish string) (string, err
 	if err != nil {
 		return "", err
 	}
+	for _, file := range f.pagesFiles {
+		if err := copyFile(file, filepath.Join(dir, filepath.Base(file))); err != nil {
+			return "", err
+		}
+	}
 	if len(f.indexFile) == 0 {
 		return dir, nil
 	}
@@ -87,9 +105,24 @@ func (f *FakeGit) GetPushURL(remote string, token string) (string, error) {
 	return pushURLWithToken, nil
 }
 
+func (f *FakeGit) Tags(workingDir string) ([]string, error) {
+	f.Called(workingDir)
+	return f.tags, nil
+}
+
+func (f *FakeGit) Log(workingDir string, revisionRange string, paths ...string) ([]git.Commit, error) {
+	f.Called(workingDir, revisionRange, paths)
+	return f.commits, nil
+}
+
 func (f *FakeGitHub) CreateRelease(ctx context.Context, input *github.Release) error {
-	f.Called(ctx, input)
+	if err := f.Called(ctx, input).Error(0); err != nil {
+		return err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
 	f.release = input
+	f.releases = append(f.releases, input)
 	return nil
 }
 
@@ -108,9 +141,51 @@ func (f *FakeGitHub) GetRelease(ctx context.Context, tag string) (*github.Releas
 			},
 		},
 	}
+	release.Assets = append(release.Assets, f.extraAssets...)
+	release.CreatedAt = f.createdAt
+	for _, asset := range release.Assets {
+		if stat, err := os.Stat(asset.Path); err == nil {
+			asset.Size = stat.Size()
+		}
+	}
 	return release, nil
 }
 
+func (f *FakeGitHub) ListReleases(ctx context.Context) ([]*github.Release, error) {
+	f.Called(ctx)
+	if f.listed != nil {
+		return f.listed, nil
+	}
+	release, _ := f.GetRelease(ctx, "test-chart-0.1.0")
+	release.Name = "test-chart-0.1.0"
+	return []*github.Release{release}, nil
+}
+
+func (f *FakeGitHub) DownloadReleaseAsset(ctx context.Context, asset *github.Asset, filename string) error {
+	f.Called(ctx, asset, filename)
+	return copyFile(asset.Path, filename)
+}
+
+func (f *FakeGitHub) DeleteRelease(ctx context.Context, tag string) error {
+	f.Called(ctx, tag)
+	return nil
+}
+
+func (f *FakeGitHub) UploadReleaseAsset(ctx context.Context, releaseID int64, filename string) error {
+	f.Called(ctx, releaseID, filename)
+	return nil
+}
+
+func (f *FakeGitHub) DeleteReleaseAsset(ctx context.Context, assetID int64) error {
+	f.Called(ctx, assetID)
+	return nil
+}
+
+func (f *FakeGitHub) UpdateRelease(ctx context.Context, input *github.Release) error {
+	f.Called(ctx, input)
+	return nil
+}
+
 func (f *FakeGitHub) CreatePullRequest(owner string, repo string, message string, head string, base string) (string, error) {
 	f.Called(owner, repo, message, head, base)
 	return "https://github.com/owner/repo/pull/42", nil
@@ -245,6 +320,188 @@ func TestReleaser_UpdateIndexFileGenerated(t *testing.T) {
 	}
 }
 
+func TestReleaser_UpdateIndexFileMultipleCharts(t *testing.T) {
+	indexDir := t.TempDir()
+
+	// Other .tgz files attached to the release are skipped
+	notAChart := filepath.Join(t.TempDir(), "docs-1.0.0.tgz")
+	require.NoError(t, os.WriteFile(notAChart, []byte("not a chart"), 0644))
+
+	fakeGitHub := new(FakeGitHub)
+	fakeGitHub.extraAssets = []*github.Asset{
+		{
+			Path: "testdata/multi-packages/sub-chart-0.1.0.tgz",
+			URL:  "https://myrepo/charts/sub-chart-0.1.0.tgz",
+		},
+		{
+			Path: notAChart,
+			URL:  "https://myrepo/charts/docs.tgz",
+		},
+		{
+			Path: notAChart,
+			URL:  "https://myrepo/charts/docs-1.0.0.tgz",
+		},
+	}
+	fakeGitHub.On("DownloadReleaseAsset", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	fakeGit := new(FakeGit)
+	fakeGit.On("RemoveWorktree", mock.Anything, mock.Anything).Return(nil)
+
+	r := &Releaser{
+		config: &config.Options{
+			IndexPath:           filepath.Join(indexDir, "index.yaml"),
+			PackagePath:         "testdata/release-packages",
+			ReleaseNameTemplate: "{{ .Name }}-{{ .Version }}",
+		},
+		github: fakeGitHub,
+		git:    fakeGit,
+	}
+
+	update, err := r.UpdateIndexFile()
+	require.NoError(t, err)
+	assert.True(t, update)
+	fakeGitHub.AssertNumberOfCalls(t, "DownloadReleaseAsset", 2)
+
+	indexFile, err := repo.LoadIndexFile(r.config.IndexPath)
+	require.NoError(t, err)
+	assert.True(t, indexFile.Has("test-chart", "0.1.0"))
+	assert.True(t, indexFile.Has("sub-chart", "0.1.0"))
+	assert.Len(t, indexFile.Entries, 2)
+}
+
+func TestReleaser_UpdateIndexFileOverwrite(t *testing.T) {
+	for _, overwrite := range []bool{false, true} {
+		t.Run(fmt.Sprintf("overwrite=%t", overwrite), func(t *testing.T) {
+			indexPath := filepath.Join(t.TempDir(), "index.yaml")
+			require.NoError(t, copyFile("testdata/repo/index.yaml", indexPath))
+
+			fakeGitHub := new(FakeGitHub)
+			fakeGit := new(FakeGit)
+			fakeGit.indexFile = "testdata/repo/index.yaml"
+			fakeGit.On("RemoveWorktree", mock.Anything, mock.Anything).Return(nil)
+			r := &Releaser{
+				config: &config.Options{
+					IndexPath:           indexPath,
+					PackagePath:         "testdata/release-packages",
+					ReleaseNameTemplate: "{{ .Name }}-{{ .Version }}",
+					Overwrite:           overwrite,
+				},
+				github: fakeGitHub,
+				git:    fakeGit,
+			}
+
+			update, err := r.UpdateIndexFile()
+			require.NoError(t, err)
+			assert.Equal(t, overwrite, update)
+
+			indexFile, err := repo.LoadIndexFile(indexPath)
+			require.NoError(t, err)
+			require.Len(t, indexFile.Entries["test-chart"], 1)
+			entry, err := indexFile.Get("test-chart", "0.1.0")
+			require.NoError(t, err)
+			digest, err := provenance.DigestFile("testdata/release-packages/test-chart-0.1.0.tgz")
+			require.NoError(t, err)
+			if overwrite {
+				assert.Equal(t, digest, entry.Digest)
+			} else {
+				assert.Equal(t, "b61c67a17ac0215b45db5d4a60677d06993c772b1412c2dc32885ef7f49e4264", entry.Digest)
+			}
+		})
+	}
+}
+
+func TestReleaser_addArchiveToIndexFile(t *testing.T) {
+	r := &Releaser{config: &config.Options{}}
+	indexFile := repo.NewIndexFile()
+
+	notAChart := filepath.Join(t.TempDir(), "docs-1.0.0.tgz")
+	require.NoError(t, os.WriteFile(notAChart, []byte("not a chart"), 0644))
+	err := r.addArchiveToIndexFile(indexFile, notAChart, "https://myrepo/charts/docs-1.0.0.tgz")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errNotChartPackage)
+	assert.Contains(t, err.Error(), "docs-1.0.0.tgz is not a helm chart package")
+
+	require.NoError(t, r.addArchiveToIndexFile(indexFile, "testdata/release-packages/test-chart-0.1.0.tgz", "https://myrepo/charts/test-chart-0.1.0.tgz"))
+	assert.True(t, indexFile.Has("test-chart", "0.1.0"))
+}
+
+func TestReleaser_UpdateIndexFileRebuild(t *testing.T) {
+	indexDir := t.TempDir()
+
+	fakeGitHub := new(FakeGitHub)
+	release, _ := fakeGitHub.GetRelease(context.TODO(), "test-chart-0.1.0")
+	release.Name = "test-chart-0.1.0"
+	draft := &github.Release{
+		Name:  "test-chart-0.1.0-draft",
+		Draft: true,
+		Assets: []*github.Asset{
+			{Path: "testdata/release-packages/test-chart-0.1.0.tgz", URL: "https://myrepo/draft/test-chart-0.1.0.tgz"},
+		},
+	}
+	fakeGitHub.listed = []*github.Release{draft, release}
+	fakeGitHub.On("ListReleases", mock.Anything).Return(nil)
+	fakeGitHub.On("DownloadReleaseAsset", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	fakeGit := new(FakeGit)
+	fakeGit.indexFile = "testdata/repo/index.yaml"
+	fakeGit.On("RemoveWorktree", mock.Anything, mock.Anything).Return(nil)
+
+	r := &Releaser{
+		config: &config.Options{
+			IndexPath:   filepath.Join(indexDir, "index.yaml"),
+			PackagePath: "testdata/does-not-exist",
+			Rebuild:     true,
+		},
+		github: fakeGitHub,
+		git:    fakeGit,
+	}
+
+	update, err := r.UpdateIndexFile()
+	require.NoError(t, err)
+	assert.True(t, update)
+	fakeGitHub.AssertNumberOfCalls(t, "ListReleases", 1)
+	fakeGitHub.AssertNumberOfCalls(t, "DownloadReleaseAsset", 1)
+
+	indexFile, err := repo.LoadIndexFile(r.config.IndexPath)
+	require.NoError(t, err)
+	assert.Len(t, indexFile.Entries, 1)
+	entry, err := indexFile.Get("test-chart", "0.1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "https://myrepo/charts/test-chart-0.1.0.tgz", entry.URLs[0])
+	assert.NotEmpty(t, entry.Digest)
+}
+
+func TestReleaser_UpdateIndexFilePrune(t *testing.T) {
+	indexDir := t.TempDir()
+
+	fakeGitHub := new(FakeGitHub)
+	fakeGitHub.listed = []*github.Release{{Name: "test-chart-0.1.0"}, {Name: "test-chart-0.2.0", Draft: true}}
+	fakeGitHub.On("ListReleases", mock.Anything).Return(nil)
+	fakeGit := new(FakeGit)
+	fakeGit.indexFile = "testdata/prune-repo/index.yaml"
+	fakeGit.On("RemoveWorktree", mock.Anything, mock.Anything).Return(nil)
+
+	r := &Releaser{
+		config: &config.Options{
+			IndexPath:           filepath.Join(indexDir, "index.yaml"),
+			PackagePath:         "testdata/does-not-exist",
+			ReleaseNameTemplate: "{{ .Name }}-{{ .Version }}",
+			Prune:               true,
+		},
+		github: fakeGitHub,
+		git:    fakeGit,
+	}
+
+	update, err := r.UpdateIndexFile()
+	require.NoError(t, err)
+	assert.True(t, update)
+
+	indexFile, err := repo.LoadIndexFile(r.config.IndexPath)
+	require.NoError(t, err)
+	assert.Len(t, indexFile.Entries, 1)
+	assert.True(t, indexFile.Has("test-chart", "0.1.0"))
+	assert.False(t, indexFile.Has("test-chart", "0.2.0"))
+	assert.False(t, indexFile.Has("other-chart", "1.0.0"))
+}
+
 func TestReleaser_splitPackageNameAndVersion(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -479,6 +736,370 @@ func TestReleaser_CreateReleases(t *testing.T) {
 	}
 }
 
+func TestReleaser_CreateReleasesGrouped(t *testing.T) {
+	fakeGitHub := new(FakeGitHub)
+	fakeGitHub.On("CreateRelease", mock.Anything, mock.Anything).Return(nil)
+	r := &Releaser{
+		config: &config.Options{
+			PackagePath:         "testdata/multi-packages",
+			ReleaseNameTemplate: "platform-{{ .AppVersion }}",
+		},
+		github: fakeGitHub,
+		git:    new(FakeGit),
+	}
+
+	err := r.CreateReleases()
+	require.NoError(t, err)
+	fakeGitHub.AssertNumberOfCalls(t, "CreateRelease", 1)
+	assert.Equal(t, "platform-1.0", fakeGitHub.release.Name)
+	require.Len(t, fakeGitHub.release.Assets, 2)
+	assert.Equal(t, "testdata/multi-packages/sub-chart-0.1.0.tgz", fakeGitHub.release.Assets[0].Path)
+	assert.Equal(t, "testdata/multi-packages/test-chart-0.1.0.tgz", fakeGitHub.release.Assets[1].Path)
+	assert.Equal(t, "## sub-chart 0.1.0\n\nA Helm subchart for Kubernetes\n\n## test-chart 0.1.0\n\nA Helm chart for Kubernetes", fakeGitHub.release.Description)
+}
+
+func TestReleaser_CreateReleasesPrerelease(t *testing.T) {
+	tests := []struct {
+		name        string
+		packagePath string
+		prerelease  bool
+		latest      string
+	}{
+		{
+			name:        "release",
+			packagePath: "testdata/release-packages",
+			prerelease:  false,
+			latest:      "true",
+		},
+		{
+			name:        "prerelease",
+			packagePath: "testdata/prerelease-packages",
+			prerelease:  true,
+			latest:      "false",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGitHub := new(FakeGitHub)
+			fakeGitHub.On("CreateRelease", mock.Anything, mock.Anything).Return(nil)
+			r := &Releaser{
+				config: &config.Options{
+					PackagePath:         tt.packagePath,
+					ReleaseNameTemplate: "{{ .Name }}-{{ .Version }}",
+					MakeReleaseLatest:   true,
+				},
+				github: fakeGitHub,
+				git:    new(FakeGit),
+			}
+
+			require.NoError(t, r.CreateReleases())
+			assert.Equal(t, tt.prerelease, fakeGitHub.release.Prerelease)
+			assert.Equal(t, tt.latest, fakeGitHub.release.MakeLatest)
+		})
+	}
+}
+
+func TestReleaser_CreateReleasesConcurrently(t *testing.T) {
+	fakeGitHub := new(FakeGitHub)
+	fakeGitHub.On("CreateRelease", mock.Anything, mock.Anything).Return(nil)
+	r := &Releaser{
+		config: &config.Options{
+			PackagePath:         "testdata/multi-packages",
+			ReleaseNameTemplate: "{{ .Name }}-{{ .Version }}",
+			Concurrency:         4,
+		},
+		github: fakeGitHub,
+		git:    new(FakeGit),
+	}
+
+	err := r.CreateReleases()
+	require.NoError(t, err)
+	fakeGitHub.AssertNumberOfCalls(t, "CreateRelease", 2)
+
+	names := make([]string, 0, len(fakeGitHub.releases))
+	for _, release := range fakeGitHub.releases {
+		names = append(names, release.Name)
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{"sub-chart-0.1.0", "test-chart-0.1.0"}, names)
+}
+
+func TestReleaser_CreateReleasesDryRun(t *testing.T) {
+	fakeGitHub := new(FakeGitHub)
+	fakeGitHub.On("CreateRelease", mock.Anything, mock.Anything).Return(nil)
+	fakeGit := new(FakeGit)
+	fakeGit.On("RemoveWorktree", mock.Anything, mock.Anything).Return(nil)
+	r := &Releaser{
+		config: &config.Options{
+			PackagePath:         "testdata/release-packages",
+			ReleaseNameTemplate: "{{ .Name }}-{{ .Version }}",
+			Commit:              "5e239bd19fbefb9eb0181ecf0c7ef73b8fe2753c",
+			MakeReleaseLatest:   true,
+			PackagesWithIndex:   true,
+			PagesBranch:         "gh-pages",
+			Push:                true,
+			DryRun:              true,
+		},
+		github: fakeGitHub,
+		git:    fakeGit,
+	}
+
+	err := r.CreateReleases()
+	require.NoError(t, err)
+	fakeGitHub.AssertNumberOfCalls(t, "CreateRelease", 0)
+	fakeGit.AssertNumberOfCalls(t, "Commit", 0)
+	fakeGit.AssertNumberOfCalls(t, "Push", 0)
+
+	plan := r.Plan()
+	require.Len(t, plan.Releases, 1)
+	assert.Equal(t, "test-chart-0.1.0", plan.Releases[0].Name)
+	assert.Equal(t, []string{"test-chart-0.1.0.tgz"}, plan.Releases[0].Assets)
+	assert.Equal(t, []string{"Publishing chart package for test-chart-0.1.0"}, plan.Commits)
+	assert.Equal(t, `push to branch "gh-pages"`, plan.Push)
+}
+
+func TestReleaser_UpdateIndexFileDryRun(t *testing.T) {
+	indexDir := t.TempDir()
+
+	fakeGit := new(FakeGit)
+	fakeGit.indexFile = "testdata/prune-repo/index.yaml"
+	fakeGit.On("RemoveWorktree", mock.Anything, mock.Anything).Return(nil)
+	fakeGitHub := new(FakeGitHub)
+	fakeGitHub.extraAssets = []*github.Asset{
+		{
+			Path: "testdata/multi-packages/sub-chart-0.1.0.tgz",
+			URL:  "https://myrepo/charts/sub-chart-0.1.0.tgz",
+		},
+	}
+	fakeGitHub.On("DownloadReleaseAsset", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	r := &Releaser{
+		config: &config.Options{
+			IndexPath:           filepath.Join(indexDir, "index.yaml"),
+			PackagePath:         "testdata/release-packages",
+			ReleaseNameTemplate: "{{ .Name }}-{{ .Version }}",
+			PagesIndexPath:      "index.yaml",
+			PagesBranch:         "gh-pages",
+			PR:                  true,
+			RetainVersions:      1,
+			DryRun:              true,
+		},
+		github: fakeGitHub,
+		git:    fakeGit,
+	}
+
+	update, err := r.UpdateIndexFile()
+	require.NoError(t, err)
+	assert.True(t, update)
+	assert.NoFileExists(t, r.config.IndexPath)
+	fakeGit.AssertNumberOfCalls(t, "Commit", 0)
+	fakeGit.AssertNumberOfCalls(t, "Push", 0)
+
+	plan := r.Plan()
+	assert.Equal(t, []*PlannedEntry{{Name: "sub-chart", Version: "0.1.0"}}, plan.AddedEntries)
+	assert.Equal(t, []*PlannedEntry{{Name: "test-chart", Version: "0.1.0"}}, plan.RemovedEntries)
+	assert.Equal(t, []string{"Update index.yaml"}, plan.Commits)
+	assert.Equal(t, `pull request against branch "gh-pages"`, plan.Push)
+}
+
+func TestReleaser_CreateReleasesDraft(t *testing.T) {
+	fakeGitHub := new(FakeGitHub)
+	fakeGitHub.On("CreateRelease", mock.Anything, mock.Anything).Return(nil)
+	r := &Releaser{
+		config: &config.Options{
+			PackagePath:         "testdata/release-packages",
+			ReleaseNameTemplate: "{{ .Name }}-{{ .Version }}",
+			DraftRelease:        true,
+		},
+		github: fakeGitHub,
+		git:    new(FakeGit),
+	}
+
+	err := r.CreateReleases()
+	require.NoError(t, err)
+	assert.True(t, fakeGitHub.release.Draft)
+}
+
+func TestReleaser_CreateReleasesAtomic(t *testing.T) {
+	tests := []struct {
+		name       string
+		atomic     bool
+		rolledBack []string
+	}{
+		{
+			name:       "atomic",
+			atomic:     true,
+			rolledBack: []string{"test-chart-0.1.0"},
+		},
+		{
+			name:       "not-atomic",
+			atomic:     false,
+			rolledBack: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGitHub := new(FakeGitHub)
+			fakeGitHub.On("CreateRelease", mock.Anything, mock.MatchedBy(func(release *github.Release) bool {
+				return release.Name == "sub-chart-0.1.0"
+			})).Return(errors.New("upload failed"))
+			fakeGitHub.On("CreateRelease", mock.Anything, mock.Anything).Return(nil)
+			fakeGitHub.On("DeleteRelease", mock.Anything, mock.Anything).Return(nil)
+			r := &Releaser{
+				config: &config.Options{
+					PackagePath:         "testdata/multi-packages",
+					ReleaseNameTemplate: "{{ .Name }}-{{ .Version }}",
+					Atomic:              tt.atomic,
+				},
+				github: fakeGitHub,
+				git:    new(FakeGit),
+			}
+
+			err := r.CreateReleases()
+			require.ErrorContains(t, err, "error creating GitHub release sub-chart-0.1.0: upload failed")
+			fakeGitHub.AssertNumberOfCalls(t, "CreateRelease", 2)
+			assert.Equal(t, tt.atomic, fakeGitHub.release.Draft)
+			fakeGitHub.AssertNumberOfCalls(t, "DeleteRelease", len(tt.rolledBack))
+			for _, name := range tt.rolledBack {
+				fakeGitHub.AssertCalled(t, "DeleteRelease", mock.Anything, name)
+			}
+		})
+	}
+}
+
+func TestReleaser_CreateReleasesResume(t *testing.T) {
+	tests := []struct {
+		name     string
+		chart    string
+		prov     bool
+		uploaded []string
+		error    bool
+	}{
+		{
+			name:     "complete-release",
+			chart:    "testdata/release-packages/test-chart-0.1.0.tgz",
+			uploaded: nil,
+		},
+		{
+			name:     "missing-prov-file",
+			chart:    "testdata/release-packages/test-chart-0.1.0.tgz",
+			prov:     true,
+			uploaded: []string{"test-chart-0.1.0.tgz.prov"},
+		},
+		{
+			name:  "different-chart-package",
+			chart: "testdata/multi-packages/sub-chart-0.1.0.tgz",
+			error: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			packagePath := t.TempDir()
+			require.NoError(t, copyFile(tt.chart, filepath.Join(packagePath, "test-chart-0.1.0.tgz")))
+			if tt.prov {
+				require.NoError(t, os.WriteFile(filepath.Join(packagePath, "test-chart-0.1.0.tgz.prov"), []byte("signature"), 0644))
+			}
+
+			fakeGitHub := new(FakeGitHub)
+			fakeGitHub.On("CreateRelease", mock.Anything, mock.Anything).Return(nil)
+			fakeGitHub.On("UploadReleaseAsset", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			fakeGitHub.On("DownloadReleaseAsset", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			r := &Releaser{
+				config: &config.Options{
+					PackagePath:         packagePath,
+					ReleaseNameTemplate: "test-chart-0.1.0",
+					Resume:              true,
+				},
+				github: fakeGitHub,
+				git:    new(FakeGit),
+			}
+
+			err := r.CreateReleases()
+			fakeGitHub.AssertNumberOfCalls(t, "CreateRelease", 0)
+			if tt.error {
+				require.Error(t, err)
+				fakeGitHub.AssertNumberOfCalls(t, "UploadReleaseAsset", 0)
+				return
+			}
+			require.NoError(t, err)
+			fakeGitHub.AssertNumberOfCalls(t, "UploadReleaseAsset", len(tt.uploaded))
+			for _, name := range tt.uploaded {
+				fakeGitHub.AssertCalled(t, "UploadReleaseAsset", mock.Anything, mock.Anything, filepath.Join(packagePath, name))
+			}
+		})
+	}
+}
+
+func TestReleaser_CreateReleasesOverwrite(t *testing.T) {
+	tests := []struct {
+		name      string
+		createdAt time.Time
+		dryRun    bool
+		error     bool
+	}{
+		{
+			name:      "recent-release",
+			createdAt: time.Now().Add(-time.Hour),
+		},
+		{
+			name:      "recent-release-dry-run",
+			createdAt: time.Now().Add(-time.Hour),
+			dryRun:    true,
+		},
+		{
+			name:      "old-release",
+			createdAt: time.Now().Add(-48 * time.Hour),
+			error:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGitHub := new(FakeGitHub)
+			fakeGitHub.createdAt = tt.createdAt
+			fakeGitHub.On("CreateRelease", mock.Anything, mock.Anything).Return(nil)
+			fakeGitHub.On("DeleteReleaseAsset", mock.Anything, mock.Anything).Return(nil)
+			fakeGitHub.On("UploadReleaseAsset", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			fakeGitHub.On("UpdateRelease", mock.Anything, mock.Anything).Return(nil)
+			r := &Releaser{
+				config: &config.Options{
+					PackagePath:         "testdata/release-packages",
+					ReleaseNameTemplate: "{{ .Name }}-{{ .Version }}",
+					Overwrite:           true,
+					OverwriteMaxAge:     24 * time.Hour,
+					DryRun:              tt.dryRun,
+				},
+				github: fakeGitHub,
+				git:    new(FakeGit),
+			}
+
+			err := r.CreateReleases()
+			fakeGitHub.AssertNumberOfCalls(t, "CreateRelease", 0)
+			if tt.error {
+				require.ErrorContains(t, err, "refusing to overwrite GitHub release test-chart-0.1.0")
+				fakeGitHub.AssertNumberOfCalls(t, "DeleteReleaseAsset", 0)
+				fakeGitHub.AssertNumberOfCalls(t, "UploadReleaseAsset", 0)
+				return
+			}
+			require.NoError(t, err)
+			if tt.dryRun {
+				fakeGitHub.AssertNumberOfCalls(t, "DeleteReleaseAsset", 0)
+				fakeGitHub.AssertNumberOfCalls(t, "UploadReleaseAsset", 0)
+				fakeGitHub.AssertNumberOfCalls(t, "UpdateRelease", 0)
+				require.Len(t, r.Plan().Releases, 1)
+				assert.True(t, r.Plan().Releases[0].Overwrite)
+				return
+			}
+			// The chart package is replaced, the third-party file is kept
+			fakeGitHub.AssertNumberOfCalls(t, "DeleteReleaseAsset", 1)
+			fakeGitHub.AssertNumberOfCalls(t, "UploadReleaseAsset", 1)
+			fakeGitHub.AssertCalled(t, "UploadReleaseAsset", mock.Anything, mock.Anything, "testdata/release-packages/test-chart-0.1.0.tgz")
+			fakeGitHub.AssertCalled(t, "UpdateRelease", mock.Anything, mock.MatchedBy(func(release *github.Release) bool {
+				return release.Description == "A Helm chart for Kubernetes"
+			}))
+			assert.Empty(t, r.created)
+		})
+	}
+}
+
 func TestReleaser_ReleaseNotes(t *testing.T) {
 	tests := []struct {
 		name                 string
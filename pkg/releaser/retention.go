@@ -0,0 +1,115 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releaser
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/repo"
+)
+
+// hasRetentionPolicy reports whether any retention rule is configured.
+func (r *Releaser) hasRetentionPolicy() bool {
+	return r.config.RetainVersions > 0 || r.config.RetainNewerThan > 0 || r.config.RetainLatestPatch
+}
+
+// applyRetentionPolicy removes all chart versions from the index file which are
+// not kept by any of the configured retention rules and returns them. The highest
+// version of each chart is always kept.
+func (r *Releaser) applyRetentionPolicy(indexFile *repo.IndexFile) []*repo.ChartVersion {
+	if !r.hasRetentionPolicy() {
+		return nil
+	}
+
+	var removed []*repo.ChartVersion
+	for name, versions := range indexFile.Entries {
+		sort.Sort(sort.Reverse(versions))
+
+		var kept repo.ChartVersions
+		latestPatches := map[string]bool{}
+		for i, version := range versions {
+			if r.retainVersion(i, version, latestPatches) {
+				kept = append(kept, version)
+				continue
+			}
+			fmt.Printf("Removing %s-%s from index (retention policy)\n", name, version.Version)
+			removed = append(removed, version)
+		}
+		if len(kept) == 0 {
+			delete(indexFile.Entries, name)
+		} else {
+			indexFile.Entries[name] = kept
+		}
+	}
+	return removed
+}
+
+// retainVersion reports whether the chart version at the given position of the
+// versions sorted in descending order is kept by the retention policy.
+// latestPatches tracks the minor versions whose latest patch was already seen.
+func (r *Releaser) retainVersion(position int, version *repo.ChartVersion, latestPatches map[string]bool) bool {
+	v, err := semver.NewVersion(version.Version)
+	if err != nil {
+		// never remove versions we do not understand
+		return true
+	}
+
+	// A retention policy never removes a chart completely, so the highest
+	// version is always kept
+	if position == 0 {
+		return true
+	}
+
+	retain := false
+	if r.config.RetainVersions > 0 && position < r.config.RetainVersions {
+		retain = true
+	}
+	if r.config.RetainNewerThan > 0 && version.Created.After(time.Now().Add(-r.config.RetainNewerThan)) {
+		retain = true
+	}
+	if r.config.RetainLatestPatch && v.Prerelease() == "" {
+		minor := fmt.Sprintf("%d.%d", v.Major(), v.Minor())
+		if !latestPatches[minor] {
+			latestPatches[minor] = true
+			retain = true
+		}
+	}
+	return retain
+}
+
+// deleteReleases deletes the GitHub releases of the given chart versions along
+// with their git tags.
+func (r *Releaser) deleteReleases(versions []*repo.ChartVersion) error {
+	for _, version := range versions {
+		releaseName, err := r.computeReleaseName(&chart.Chart{Metadata: version.Metadata})
+		if err != nil {
+			return err
+		}
+		if r.config.DryRun {
+			r.Plan().DeletedReleases = append(r.Plan().DeletedReleases, releaseName)
+			continue
+		}
+		fmt.Printf("Deleting release %s\n", releaseName)
+		if err := r.github.DeleteRelease(context.TODO(), releaseName); err != nil {
+			return fmt.Errorf("error deleting GitHub release %s: %w", releaseName, err)
+		}
+	}
+	return nil
+}
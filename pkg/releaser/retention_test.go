@@ -0,0 +1,167 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releaser
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/repo"
+
+	"github.com/helm/chart-releaser/pkg/config"
+)
+
+func TestReleaser_applyRetentionPolicy(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		config   *config.Options
+		versions map[string]time.Time
+		expected []string
+	}{
+		{
+			name:   "no-policy",
+			config: &config.Options{},
+			versions: map[string]time.Time{
+				"1.0.0": now.Add(-72 * time.Hour),
+				"1.1.0": now.Add(-48 * time.Hour),
+			},
+			expected: []string{"1.1.0", "1.0.0"},
+		},
+		{
+			name:   "retain-versions",
+			config: &config.Options{RetainVersions: 2},
+			versions: map[string]time.Time{
+				"1.0.0": now.Add(-72 * time.Hour),
+				"1.0.1": now.Add(-60 * time.Hour),
+				"1.1.0": now.Add(-48 * time.Hour),
+				"2.0.0": now.Add(-24 * time.Hour),
+			},
+			expected: []string{"2.0.0", "1.1.0"},
+		},
+		{
+			name:   "retain-newer-than",
+			config: &config.Options{RetainNewerThan: 50 * time.Hour},
+			versions: map[string]time.Time{
+				"1.0.0": now.Add(-72 * time.Hour),
+				"1.0.1": now.Add(-60 * time.Hour),
+				"1.1.0": now.Add(-48 * time.Hour),
+				"2.0.0": now.Add(-24 * time.Hour),
+			},
+			expected: []string{"2.0.0", "1.1.0"},
+		},
+		{
+			name:   "retain-latest-patch",
+			config: &config.Options{RetainVersions: 1, RetainLatestPatch: true},
+			versions: map[string]time.Time{
+				"1.0.0":       now.Add(-72 * time.Hour),
+				"1.0.1":       now.Add(-60 * time.Hour),
+				"1.1.0":       now.Add(-48 * time.Hour),
+				"1.1.1-rc.1":  now.Add(-36 * time.Hour),
+				"2.0.0":       now.Add(-24 * time.Hour),
+				"2.1.0-alpha": now.Add(-12 * time.Hour),
+			},
+			expected: []string{"2.1.0-alpha", "2.0.0", "1.1.0", "1.0.1"},
+		},
+		{
+			name:   "prereleases only",
+			config: &config.Options{RetainLatestPatch: true},
+			versions: map[string]time.Time{
+				"1.0.0-rc.1": now.Add(-48 * time.Hour),
+				"1.0.0-rc.2": now.Add(-24 * time.Hour),
+			},
+			expected: []string{"1.0.0-rc.2"},
+		},
+		{
+			name:   "all versions expired",
+			config: &config.Options{RetainNewerThan: 2160 * time.Hour},
+			versions: map[string]time.Time{
+				"1.0.0": now.Add(-300 * 24 * time.Hour),
+				"1.1.0": now.Add(-200 * 24 * time.Hour),
+			},
+			expected: []string{"1.1.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indexFile := repo.NewIndexFile()
+			for version, created := range tt.versions {
+				require.NoError(t, indexFile.MustAdd(&chart.Metadata{
+					APIVersion: chart.APIVersionV2,
+					Name:       "test-chart",
+					Version:    version,
+				}, "test-chart-"+version+".tgz", "https://myrepo/charts", ""))
+				cv, _ := indexFile.Get("test-chart", version)
+				cv.Created = created
+			}
+
+			r := &Releaser{config: tt.config}
+			removed := r.applyRetentionPolicy(indexFile)
+			assert.Len(t, removed, len(tt.versions)-len(tt.expected))
+
+			var actual []string
+			for _, cv := range indexFile.Entries["test-chart"] {
+				actual = append(actual, cv.Version)
+			}
+			assert.ElementsMatch(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestReleaser_UpdateIndexFileRetentionDeleteReleases(t *testing.T) {
+	indexDir := t.TempDir()
+
+	fakeGitHub := new(FakeGitHub)
+	fakeGitHub.On("DeleteRelease", mock.Anything, mock.Anything).Return(nil)
+	fakeGit := new(FakeGit)
+	fakeGit.indexFile = "testdata/prune-repo/index.yaml"
+	fakeGit.On("RemoveWorktree", mock.Anything, mock.Anything).Return(nil)
+	fakeGit.On("Pull", mock.Anything, mock.Anything).Return(nil)
+	fakeGit.On("Add", mock.Anything, mock.Anything).Return(nil)
+	fakeGit.On("Commit", mock.Anything, mock.Anything).Return(nil)
+	fakeGit.On("GetPushURL", mock.Anything, mock.Anything).Return(nil)
+	fakeGit.On("Push", mock.Anything, mock.Anything).Return(nil)
+
+	r := &Releaser{
+		config: &config.Options{
+			IndexPath:               filepath.Join(indexDir, "index.yaml"),
+			PackagePath:             "testdata/does-not-exist",
+			ReleaseNameTemplate:     "{{ .Name }}-{{ .Version }}",
+			RetainVersions:          1,
+			RetentionDeleteReleases: true,
+			Push:                    true,
+		},
+		github: fakeGitHub,
+		git:    fakeGit,
+	}
+
+	update, err := r.UpdateIndexFile()
+	require.NoError(t, err)
+	assert.True(t, update)
+	fakeGit.AssertCalled(t, "Push", mock.Anything, mock.Anything)
+	fakeGitHub.AssertNumberOfCalls(t, "DeleteRelease", 1)
+	fakeGitHub.AssertCalled(t, "DeleteRelease", mock.Anything, "test-chart-0.1.0")
+
+	indexFile, err := repo.LoadIndexFile(r.config.IndexPath)
+	require.NoError(t, err)
+	assert.True(t, indexFile.Has("test-chart", "0.2.0"))
+	assert.False(t, indexFile.Has("test-chart", "0.1.0"))
+	assert.True(t, indexFile.Has("other-chart", "1.0.0"))
+}
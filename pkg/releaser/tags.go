@@ -0,0 +1,40 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releaser
+
+import (
+	"github.com/Masterminds/semver/v3"
+	"helm.sh/helm/v3/pkg/chart"
+
+	"github.com/helm/chart-releaser/pkg/releasename"
+)
+
+// previousReleaseTag returns the tag of the highest release of the given chart
+// below the chart version, or an empty string if there is none.
+func (r *Releaser) previousReleaseTag(metadata *chart.Metadata) (string, error) {
+	current, err := semver.NewVersion(metadata.Version)
+	if err != nil {
+		return "", err
+	}
+	tagRegexp, err := releasename.Regexp(r.config.ReleaseNameTemplate, metadata)
+	if err != nil {
+		return "", err
+	}
+	tags, err := r.git.Tags("")
+	if err != nil {
+		return "", err
+	}
+	return releasename.HighestTag(tagRegexp, tags, current), nil
+}
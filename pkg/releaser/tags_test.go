@@ -0,0 +1,84 @@
+// Copyright The Helm Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releaser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart"
+
+	"github.com/helm/chart-releaser/pkg/config"
+)
+
+func TestReleaser_previousReleaseTag(t *testing.T) {
+	tags := []string{
+		"other-chart-1.0.0",
+		"test-chart-0.9.0",
+		"test-chart-1.0.0",
+		"test-chart-1.1.0-rc.1",
+		"test-chart-1.1.0",
+		"test-chart-2.0.0",
+		"test-chart-extra-1.0.5",
+		"v1.0.9",
+	}
+	tests := []struct {
+		name     string
+		template string
+		version  string
+		expected string
+	}{
+		{
+			name:     "default-template",
+			template: "{{ .Name }}-{{ .Version }}",
+			version:  "1.2.0",
+			expected: "test-chart-1.1.0",
+		},
+		{
+			name:     "prerelease",
+			template: "{{ .Name }}-{{ .Version }}",
+			version:  "1.1.0",
+			expected: "test-chart-1.1.0-rc.1",
+		},
+		{
+			name:     "first-release",
+			template: "{{ .Name }}-{{ .Version }}",
+			version:  "0.9.0",
+			expected: "",
+		},
+		{
+			name:     "version-only-template",
+			template: "v{{ .Version }}",
+			version:  "1.1.0",
+			expected: "v1.0.9",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGit := new(FakeGit)
+			fakeGit.tags = tags
+			fakeGit.On("Tags", mock.Anything).Return(nil)
+			r := &Releaser{
+				config: &config.Options{ReleaseNameTemplate: tt.template},
+				git:    fakeGit,
+			}
+			tag, err := r.previousReleaseTag(&chart.Metadata{Name: "test-chart", Version: tt.version})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, tag)
+		})
+	}
+}